@@ -2,10 +2,15 @@ package ydb
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/ydb-platform/gorm-driver/internal/dialect"
 )
@@ -32,6 +37,149 @@ func WithConnMaxIdleTime(d time.Duration) Option {
 	return dialect.WithConnMaxIdleTime(d)
 }
 
+func WithTypedParams() Option {
+	return dialect.WithTypedParams()
+}
+
+func WithQueryService() Option {
+	return dialect.WithQueryService()
+}
+
+var (
+	ErrSchemeError = dialect.ErrSchemeError
+	ErrOverloaded  = dialect.ErrOverloaded
+	ErrTimeout     = dialect.ErrTimeout
+)
+
+type (
+	Error = dialect.Error
+	Issue = dialect.Issue
+	Op    = dialect.Op
+)
+
+const (
+	OpCreate  = dialect.OpCreate
+	OpQuery   = dialect.OpQuery
+	OpUpdate  = dialect.OpUpdate
+	OpDelete  = dialect.OpDelete
+	OpRow     = dialect.OpRow
+	OpRaw     = dialect.OpRaw
+	OpMigrate = dialect.OpMigrate
+)
+
+var ErrSavePointNotSupported = dialect.ErrSavePointNotSupported
+
+type SavePointPolicy = dialect.SavePointPolicy
+
+const (
+	SavePointError   = dialect.SavePointError
+	SavePointFlatten = dialect.SavePointFlatten
+)
+
+func WithSavePointPolicy(policy SavePointPolicy) Option {
+	return dialect.WithSavePointPolicy(policy)
+}
+
+var ErrLockingNotSupported = dialect.ErrLockingNotSupported
+
+type LockingPolicy = dialect.LockingPolicy
+
+const (
+	LockingError  = dialect.LockingError
+	LockingIgnore = dialect.LockingIgnore
+	LockingWarn   = dialect.LockingWarn
+)
+
+func WithLockingPolicy(policy LockingPolicy) Option {
+	return dialect.WithLockingPolicy(policy)
+}
+
+var ErrTruncated = dialect.ErrTruncated
+
+type TruncatedPolicy = dialect.TruncatedPolicy
+
+const (
+	TruncatedError = dialect.TruncatedError
+	TruncatedScan  = dialect.TruncatedScan
+)
+
+func WithTruncatedPolicy(policy TruncatedPolicy) Option {
+	return dialect.WithTruncatedPolicy(policy)
+}
+
+var ErrIndexNotFound = dialect.ErrIndexNotFound
+
+func UseIndex(name string) clause.Expression {
+	return dialect.UseIndex(name)
+}
+
+func WithPragma(name string, values ...string) Option {
+	return dialect.WithPragma(name, values...)
+}
+
+func Pragma(name string, values ...string) clause.Expression {
+	return dialect.Pragma(name, values...)
+}
+
+func Named(name string, value interface{}) clause.Expression {
+	return dialect.Named(name, value)
+}
+
+type ConsumedUnits = dialect.ConsumedUnits
+
+func WithConsumedUnitsHook(hook func(ctx context.Context, units ConsumedUnits)) Option {
+	return dialect.WithConsumedUnitsHook(hook)
+}
+
+type (
+	StatementHook     = dialect.StatementHook
+	StatementInfo     = dialect.StatementInfo
+	StatementDoneInfo = dialect.StatementDoneInfo
+)
+
+func WithStatementHook(hook StatementHook) Option {
+	return dialect.WithStatementHook(hook)
+}
+
+type DriverLoggerOption = dialect.DriverLoggerOption
+
+func WithDriverLogLevel(level log.Level) DriverLoggerOption {
+	return dialect.WithDriverLogLevel(level)
+}
+
+func WithDriverLogDetails(details ydbTrace.Details) DriverLoggerOption {
+	return dialect.WithDriverLogDetails(details)
+}
+
+func WithDriverSlowThreshold(threshold time.Duration) DriverLoggerOption {
+	return dialect.WithDriverSlowThreshold(threshold)
+}
+
+func WithDriverLogger(opts ...DriverLoggerOption) Option {
+	return dialect.WithDriverLogger(opts...)
+}
+
+type (
+	QueryStatsMode = dialect.QueryStatsMode
+	QueryStats     = dialect.QueryStats
+	TableStats     = dialect.TableStats
+)
+
+const (
+	QueryStatsNone    = dialect.QueryStatsNone
+	QueryStatsBasic   = dialect.QueryStatsBasic
+	QueryStatsFull    = dialect.QueryStatsFull
+	QueryStatsProfile = dialect.QueryStatsProfile
+)
+
+func WithQueryStatsCollection() Option {
+	return dialect.WithQueryStatsCollection()
+}
+
+func WithQueryStats(ctx context.Context, mode QueryStatsMode, fc func(stats QueryStats)) context.Context {
+	return dialect.WithQueryStats(ctx, mode, fc)
+}
+
 type QueryMode = ydb.QueryMode
 
 const (
@@ -46,6 +194,95 @@ func WithQueryMode(ctx context.Context, mode QueryMode) context.Context {
 	return ydb.WithQueryMode(ctx, mode)
 }
 
+type TxMode = dialect.TxMode
+
+const (
+	SerializableReadWriteTxMode      = dialect.SerializableReadWriteTxMode
+	SnapshotReadOnlyTxMode           = dialect.SnapshotReadOnlyTxMode
+	OnlineReadOnlyTxMode             = dialect.OnlineReadOnlyTxMode
+	OnlineReadOnlyInconsistentTxMode = dialect.OnlineReadOnlyInconsistentTxMode
+	StaleReadOnlyTxMode              = dialect.StaleReadOnlyTxMode
+)
+
+func WithTxMode(ctx context.Context, mode TxMode) context.Context {
+	return dialect.WithTxMode(ctx, mode)
+}
+
+type RetryOption = dialect.RetryOption
+
+func WithRetryIdempotent(idempotent bool) RetryOption {
+	return dialect.WithRetryIdempotent(idempotent)
+}
+
+func WithRetryAttempts(attempts int) RetryOption {
+	return dialect.WithRetryAttempts(attempts)
+}
+
+func WithRetryTxOptions(opts *sql.TxOptions) RetryOption {
+	return dialect.WithRetryTxOptions(opts)
+}
+
+func WithRetryHook(hook func(attempt int, err error)) RetryOption {
+	return dialect.WithRetryHook(hook)
+}
+
+func WithRetryOptions(opts ...retry.Option) RetryOption {
+	return dialect.WithRetryOptions(opts...)
+}
+
+func Retry(db *gorm.DB, fc func(tx *gorm.DB) error, opts ...RetryOption) error {
+	return dialect.Retry(db, fc, opts...)
+}
+
+func Transaction(db *gorm.DB, fc func(tx *gorm.DB) error, opts ...RetryOption) error {
+	return dialect.Transaction(db, fc, opts...)
+}
+
+func BulkCreate(db *gorm.DB, value interface{}) error {
+	return dialect.BulkCreate(db, value)
+}
+
+func UpdateOn(db *gorm.DB, source interface{}) *gorm.DB {
+	return dialect.UpdateOn(db, source)
+}
+
+func DeleteOn(db *gorm.DB, source interface{}) *gorm.DB {
+	return dialect.DeleteOn(db, source)
+}
+
+func Stream[T any](db *gorm.DB, fc func(row *T) error) error {
+	return dialect.Stream(db, fc)
+}
+
+type ReadTableOption = dialect.ReadTableOption
+
+func WithReadTableWorkers(workers int) ReadTableOption {
+	return dialect.WithReadTableWorkers(workers)
+}
+
+func ReadTable[T any](db *gorm.DB, fc func(row *T) error, opts ...ReadTableOption) error {
+	return dialect.ReadTable(db, fc, opts...)
+}
+
+type (
+	Plan         = dialect.Plan
+	PlanStage    = dialect.PlanStage
+	PlanOperator = dialect.PlanOperator
+	TableAccess  = dialect.TableAccess
+	TableRead    = dialect.TableRead
+	TableWrite   = dialect.TableWrite
+)
+
+func ExplainPlan(db *gorm.DB) (*Plan, error) {
+	return dialect.ExplainPlan(db)
+}
+
+var ErrInvalidPageToken = dialect.ErrInvalidPageToken
+
+func Paginate[T any](db *gorm.DB, token string, limit int) ([]T, string, error) {
+	return dialect.Paginate[T](db, token, limit)
+}
+
 func Open(dsn string, opts ...Option) gorm.Dialector {
 	return dialect.New(dsn, opts...)
 }
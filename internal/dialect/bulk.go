@@ -0,0 +1,147 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// BulkCreate upserts value (struct, slice or array of structs) into model table with table service BulkUpsert.
+// Batch size is taken from gorm CreateBatchSize (all rows in single request by default).
+// BulkCreate does not run gorm hooks and callbacks.
+func BulkCreate(db *gorm.DB, value interface{}) error {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx := db.WithContext(ctx)
+
+	if err := tx.Statement.Parse(value); err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("error parsing schema: %w", err))
+	}
+
+	rows, err := bulkRows(tx.Statement.Context, bulkFields(tx.Statement.Schema), reflect.ValueOf(value))
+	if err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if len(rows) == 0 {
+		return nil
+	}
+
+	m, ok := tx.Migrator().(Migrator)
+	if !ok {
+		return xerrors.WithStacktrace(errors.New("error conversion to Migrator"))
+	}
+
+	tablePath, err := m.fullTableName(tx.Statement.Table)
+	if err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	sqlDB, err := tx.DB()
+	if err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("error getting database/sql driver from gorm: %w", err))
+	}
+
+	cc, err := ydbDriver.Unwrap(sqlDB)
+	if err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("ydb driver unwrap failed: %w", err))
+	}
+
+	batchSize := tx.CreateBatchSize
+	if batchSize <= 0 {
+		batchSize = len(rows)
+	}
+
+	for i := 0; i < len(rows); i += batchSize {
+		batch := rows[i:min(i+batchSize, len(rows))]
+
+		err = cc.Table().Do(tx.Statement.Context, func(ctx context.Context, s table.Session) error {
+			return s.BulkUpsert(ctx, tablePath, types.ListValue(batch...))
+		}, table.WithIdempotent())
+		if err != nil {
+			return xerrors.WithStacktrace(fmt.Errorf("bulk upsert into '%s' failed: %w", tablePath, err))
+		}
+	}
+
+	return nil
+}
+
+// bulkFields returns fields of schema columns, as they created by Migrator.
+func bulkFields(s *schema.Schema) []*schema.Field {
+	fields := make([]*schema.Field, 0, len(s.DBNames))
+
+	for _, dbName := range s.DBNames {
+		field := s.FieldsByDBName[dbName]
+		if field.IgnoreMigration || !field.Creatable {
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// bulkRows converts rv (struct, slice or array of structs) to list of ydb Struct values with fields.
+func bulkRows(ctx context.Context, fields []*schema.Field, rv reflect.Value) ([]types.Value, error) {
+	rv = reflect.Indirect(rv)
+
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Array:
+		rows := make([]types.Value, 0, rv.Len())
+		for i := 0; i < rv.Len(); i++ {
+			row, err := bulkRow(ctx, fields, reflect.Indirect(rv.Index(i)))
+			if err != nil {
+				return nil, xerrors.WithStacktrace(fmt.Errorf("row %d: %w", i, err))
+			}
+
+			rows = append(rows, row)
+		}
+
+		return rows, nil
+	case reflect.Struct:
+		row, err := bulkRow(ctx, fields, rv)
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		return []types.Value{row}, nil
+	default:
+		return nil, xerrors.WithStacktrace(fmt.Errorf("unsupported value kind '%s'", rv.Kind()))
+	}
+}
+
+// bulkRow converts struct rv to ydb Struct value with fields typed as in CREATE TABLE.
+func bulkRow(ctx context.Context, fields []*schema.Field, rv reflect.Value) (types.Value, error) {
+	values := make([]types.StructValueOption, 0, len(fields))
+
+	for _, field := range fields {
+		t, err := fieldType(field)
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		fieldValue, _ := field.ValueOf(ctx, rv)
+
+		v, err := toValue(t, fieldValue)
+		if err != nil {
+			return nil, xerrors.WithStacktrace(fmt.Errorf("field %s: %w", field.Name, err))
+		}
+
+		values = append(values, types.StructFieldValue(field.DBName, v))
+	}
+
+	return types.StructValue(values...), nil
+}
@@ -0,0 +1,79 @@
+package dialect
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+func Test_bulkRows(t *testing.T) {
+	type Product struct {
+		ID    uint64 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  *string
+		Price uint32
+	}
+
+	s, err := schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	code := "D42"
+
+	t.Run("slice", func(t *testing.T) {
+		rows, err := bulkRows(context.Background(), bulkFields(s), reflect.ValueOf(&[]Product{
+			{ID: 1, Code: &code, Price: 100},
+			{ID: 2, Price: 200},
+		}))
+		require.NoError(t, err)
+		require.Equal(t, []types.Value{
+			types.StructValue(
+				types.StructFieldValue("id", types.Uint64Value(1)),
+				types.StructFieldValue("code", types.OptionalValue(types.TextValue(code))),
+				types.StructFieldValue("price", types.OptionalValue(types.Uint32Value(100))),
+			),
+			types.StructValue(
+				types.StructFieldValue("id", types.Uint64Value(2)),
+				types.StructFieldValue("code", types.NullValue(types.TypeText)),
+				types.StructFieldValue("price", types.OptionalValue(types.Uint32Value(200))),
+			),
+		}, rows)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		rows, err := bulkRows(context.Background(), bulkFields(s), reflect.ValueOf(&Product{ID: 3}))
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := bulkRows(context.Background(), bulkFields(s), reflect.ValueOf(42))
+		require.Error(t, err)
+	})
+}
+
+func TestBulkCreate(t *testing.T) {
+	type Product struct {
+		ID   uint64 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	db, err := gorm.Open(&Dialector{Conn: &fakePool{}}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	t.Run("statement of db is not changed", func(t *testing.T) {
+		tx := db.Where("id > ?", 1)
+
+		require.Error(t, BulkCreate(tx, &Product{ID: 1, Code: "D42"}))
+		require.Nil(t, tx.Statement.Schema)
+		require.Empty(t, tx.Statement.Table)
+	})
+}
@@ -0,0 +1,192 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"gorm.io/gorm"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+var (
+	_ gorm.ConnPool         = connPool{}
+	_ gorm.ConnPoolBeginner = connPool{}
+	_ gorm.GetDBConnector   = connPool{}
+
+	_ gorm.Tx             = &txControlPool{}
+	_ gorm.GetDBConnector = &txControlPool{}
+)
+
+// connPool is wrapper over gorm.ConnPool which maps sql.TxOptions to ydb transaction modes.
+type connPool struct {
+	gorm.ConnPool
+}
+
+// BeginTx starts interactive transaction for SerializableReadWrite and SnapshotReadOnly modes.
+// Other read-only modes are not allowed for interactive transactions in ydb, so queries of such transaction
+// are executed with own transaction control and commit.
+func (c connPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	mode, err := txModeOf(ctx, opts)
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	if !mode.interactive() {
+		return &txControlPool{
+			ConnPool: c.ConnPool,
+			mode:     mode,
+		}, nil
+	}
+
+	switch beginner := c.ConnPool.(type) {
+	case gorm.TxBeginner:
+		tx, err := beginner.BeginTx(ctx, txOptions(mode))
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		return tx, nil
+	case gorm.ConnPoolBeginner:
+		tx, err := beginner.BeginTx(ctx, txOptions(mode))
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		return tx, nil
+	default:
+		return nil, xerrors.WithStacktrace(gorm.ErrInvalidTransaction)
+	}
+}
+
+func (c connPool) GetDBConn() (*sql.DB, error) {
+	return sqlDB(c.ConnPool)
+}
+
+func (c connPool) Ping() error {
+	if pinger, ok := c.ConnPool.(interface{ Ping() error }); ok {
+		return pinger.Ping()
+	}
+
+	return nil
+}
+
+// txControlPool is gorm.ConnPool of non-interactive read-only transaction.
+type txControlPool struct {
+	gorm.ConnPool
+
+	mode TxMode
+}
+
+func (c *txControlPool) withTxControl(ctx context.Context) context.Context {
+	return ydbDriver.WithTxControl(ctx, table.TxControl(table.BeginTx(c.mode.txSettings()), table.CommitTx()))
+}
+
+func (c *txControlPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return c.ConnPool.PrepareContext(c.withTxControl(ctx), query)
+}
+
+func (c *txControlPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return c.ConnPool.ExecContext(c.withTxControl(ctx), query, args...)
+}
+
+func (c *txControlPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return c.ConnPool.QueryContext(c.withTxControl(ctx), query, args...)
+}
+
+func (c *txControlPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	return c.ConnPool.QueryRowContext(c.withTxControl(ctx), query, args...)
+}
+
+// StmtContext returns stmt as is: prepared statements of database/sql are not bound to ydb transactions, so tx
+// control of prepared statements is applied to statement context by beforeTxControl.
+func (c *txControlPool) StmtContext(_ context.Context, stmt *sql.Stmt) *sql.Stmt {
+	return stmt
+}
+
+func (c *txControlPool) Commit() error {
+	return nil
+}
+
+func (c *txControlPool) Rollback() error {
+	return nil
+}
+
+func (c *txControlPool) GetDBConn() (*sql.DB, error) {
+	return sqlDB(c.ConnPool)
+}
+
+const txControlKey = "ydb:tx_control"
+
+// beforeTxControl applies tx control of non-interactive transaction to statement context of prepared statements.
+// gorm executes prepared statements of transaction with statement context (PrepareStmt option), so tx control
+// is not applied by ExecContext and QueryContext of txControlPool.
+func beforeTxControl(db *gorm.DB) {
+	tx, ok := db.Statement.ConnPool.(*gorm.PreparedStmtTX)
+	if !ok {
+		return
+	}
+
+	pool, ok := tx.Tx.(*txControlPool)
+	if !ok {
+		return
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	db.InstanceSet(txControlKey, db.Statement.Context)
+	db.Statement.Context = pool.withTxControl(ctx)
+}
+
+// afterTxControl restores statement context replaced by beforeTxControl.
+func afterTxControl(db *gorm.DB) {
+	if v, ok := db.InstanceGet(txControlKey); ok {
+		ctx, _ := v.(context.Context)
+		db.Statement.Context = ctx
+	}
+}
+
+func registerTxControlCallbacks(db *gorm.DB) error {
+	const (
+		before = "ydb:before_tx_control"
+		after  = "ydb:after_tx_control"
+	)
+
+	for _, err := range []error{
+		db.Callback().Create().Before("gorm:create").Register(before, beforeTxControl),
+		db.Callback().Create().After("gorm:create").Register(after, afterTxControl),
+		db.Callback().Query().Before("gorm:query").Register(before, beforeTxControl),
+		db.Callback().Query().After("gorm:query").Register(after, afterTxControl),
+		db.Callback().Update().Before("gorm:update").Register(before, beforeTxControl),
+		db.Callback().Update().After("gorm:update").Register(after, afterTxControl),
+		db.Callback().Delete().Before("gorm:delete").Register(before, beforeTxControl),
+		db.Callback().Delete().After("gorm:delete").Register(after, afterTxControl),
+		db.Callback().Row().Before("gorm:row").Register(before, beforeTxControl),
+		db.Callback().Row().After("gorm:row").Register(after, afterTxControl),
+		db.Callback().Raw().Before("gorm:raw").Register(before, beforeTxControl),
+		db.Callback().Raw().After("gorm:raw").Register(after, afterTxControl),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func sqlDB(pool gorm.ConnPool) (*sql.DB, error) {
+	switch db := pool.(type) {
+	case *sql.DB:
+		return db, nil
+	case gorm.GetDBConnector:
+		return db.GetDBConn()
+	default:
+		return nil, xerrors.WithStacktrace(errors.New("database/sql driver not found in connection pool"))
+	}
+}
@@ -0,0 +1,144 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestConnPool_BeginTx(t *testing.T) {
+	t.Run("interactive", func(t *testing.T) {
+		pool := &fakePool{}
+
+		tx, err := connPool{ConnPool: pool}.BeginTx(context.Background(), &sql.TxOptions{ReadOnly: true})
+		require.NoError(t, err)
+		require.Equal(t, (*fakeTx)(pool), tx)
+		require.Equal(t, []*sql.TxOptions{{Isolation: sql.LevelSnapshot, ReadOnly: true}}, pool.txOpts)
+	})
+
+	t.Run("tx control", func(t *testing.T) {
+		pool := &fakePool{}
+
+		tx, err := connPool{ConnPool: pool}.BeginTx(
+			WithTxMode(context.Background(), OnlineReadOnlyTxMode), nil,
+		)
+		require.NoError(t, err)
+		require.Empty(t, pool.txOpts)
+		require.Equal(t, &txControlPool{ConnPool: pool, mode: OnlineReadOnlyTxMode}, tx)
+
+		_, err = tx.ExecContext(context.Background(), "SELECT 1")
+		require.NoError(t, err)
+		require.Equal(t, []string{"SELECT 1"}, pool.queries)
+		require.NotEqual(t, context.Background(), pool.contexts[0], "tx control not applied")
+
+		committer, ok := tx.(gorm.TxCommitter)
+		require.True(t, ok)
+		require.NoError(t, committer.Commit())
+		require.NoError(t, committer.Rollback())
+	})
+
+	t.Run("unsupported options", func(t *testing.T) {
+		_, err := connPool{ConnPool: &fakePool{}}.BeginTx(
+			context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead},
+		)
+		require.Error(t, err)
+	})
+}
+
+func TestConnPool_GetDBConn(t *testing.T) {
+	db := &sql.DB{}
+
+	conn, err := connPool{ConnPool: db}.GetDBConn()
+	require.NoError(t, err)
+	require.Equal(t, db, conn)
+
+	conn, err = (&txControlPool{ConnPool: connPool{ConnPool: db}}).GetDBConn()
+	require.NoError(t, err)
+	require.Equal(t, db, conn)
+
+	_, err = connPool{ConnPool: &fakePool{}}.GetDBConn()
+	require.Error(t, err)
+}
+
+func TestConnPool_Conn(t *testing.T) {
+	pool := &fakePool{}
+
+	db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	tx := db.Begin(&sql.TxOptions{ReadOnly: true})
+	require.NoError(t, tx.Error)
+	require.Equal(t, []*sql.TxOptions{{Isolation: sql.LevelSnapshot, ReadOnly: true}}, pool.txOpts)
+
+	tx = db.WithContext(WithTxMode(context.Background(), OnlineReadOnlyTxMode)).Begin()
+	require.NoError(t, tx.Error)
+	require.Equal(t, &txControlPool{ConnPool: pool, mode: OnlineReadOnlyTxMode}, tx.Statement.ConnPool)
+	require.Len(t, pool.txOpts, 1)
+
+	t.Run("tx control", func(t *testing.T) {
+		type Product struct {
+			ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+			Code string
+		}
+
+		pool := &fakePool{}
+
+		db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			return tx.Exec("SELECT 1").Error
+		}, &sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: true})
+		require.NoError(t, err)
+
+		ctx := WithTxMode(context.Background(), StaleReadOnlyTxMode)
+		require.NoError(t, db.WithContext(ctx).Begin().Commit().Error)
+		require.NoError(t, db.WithContext(ctx).Begin().Rollback().Error)
+
+		// default transaction of create is committed
+		require.NoError(t, db.WithContext(ctx).Create(&Product{ID: 1, Code: "D42"}).Error)
+
+		require.Empty(t, pool.txOpts)
+		require.Len(t, pool.queries, 2)
+		require.NotEqual(t, context.Background(), pool.contexts[0], "tx control not applied")
+		require.NotEqual(t, ctx, pool.contexts[1], "tx control not applied")
+	})
+
+	t.Run("prepared statements", func(t *testing.T) {
+		prepareErr := errors.New("prepare error")
+
+		db, err := gorm.Open(&Dialector{Conn: &fakePool{err: prepareErr}}, &gorm.Config{
+			DisableAutomaticPing: true,
+			PrepareStmt:          true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+
+		// prepared statements of transaction are executed with statement context
+		var stmtCtx context.Context
+		require.NoError(t, db.Callback().Raw().Before("gorm:raw").After("ydb:before_tx_control").
+			Register("test:context", func(db *gorm.DB) {
+				stmtCtx = db.Statement.Context
+			}))
+
+		ctx := WithTxMode(context.Background(), OnlineReadOnlyTxMode)
+
+		tx := db.WithContext(ctx).Begin()
+		require.NoError(t, tx.Error)
+
+		exec := tx.Exec("SELECT 1")
+		require.ErrorIs(t, exec.Error, prepareErr)
+		require.NotEqual(t, ctx, stmtCtx, "tx control not applied")
+		require.Equal(t, ctx, exec.Statement.Context, "statement context not restored")
+
+		require.NoError(t, tx.Commit().Error)
+	})
+}
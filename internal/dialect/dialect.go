@@ -9,10 +9,10 @@ import (
 	"time"
 
 	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/config"
 	"gorm.io/gorm"
 	"gorm.io/gorm/callbacks"
 	"gorm.io/gorm/clause"
-	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 
@@ -57,6 +57,26 @@ func WithConnMaxIdleTime(connMaxIdleTime time.Duration) Option {
 	}
 }
 
+// WithTypedParams enables typing of query parameters by schema.Field of target column.
+// Parameters are named ($p0, $p1, ...) and declared by driver with column types (Optional<T> for nullable
+// columns), for example `DECLARE $p0 AS Optional<Uint16>;`. Values of comparisons of columns (including raw
+// expressions as Where("price > ?", 10) and columns of joined relationships) are typed, other parameters are
+// declared with types of go values. Parameters are not declared by ydb-go-sdk, so connection of Dialector.Conn
+// must be created without ydb.WithAutoDeclare option.
+func WithTypedParams() Option {
+	return func(d *Dialector) {
+		d.typedParams = true
+	}
+}
+
+// WithQueryService switches Dialector to database/sql driver over YDB query service client.
+// Query service does not need query modes, allows to mix DDL and DML and streams large results.
+func WithQueryService() Option {
+	return func(d *Dialector) {
+		d.queryService = true
+	}
+}
+
 // Dialector is implementation of gorm.Dialector.
 type Dialector struct {
 	DSN  string
@@ -67,12 +87,26 @@ type Dialector struct {
 	maxOpenConns    int
 	maxIdleConns    int
 	connMaxIdleTime time.Duration
+	typedParams     bool
+	queryService    bool
+	queryStats      bool
+	savePointPolicy SavePointPolicy
+	lockingPolicy   LockingPolicy
+	truncatedPolicy TruncatedPolicy
+	pragmas         []pragma
+
+	consumedUnitsHook func(ctx context.Context, units ConsumedUnits)
+	hooks             []StatementHook
+	driverLogger      *driverLogger
+
+	indexes *sync.Map
 }
 
 // New is constructor for Dialector.
 func New(dsn string, opts ...Option) *Dialector {
 	d := &Dialector{
-		DSN: dsn,
+		DSN:     dsn,
+		indexes: &sync.Map{},
 	}
 
 	for _, opt := range opts {
@@ -84,6 +118,18 @@ func New(dsn string, opts ...Option) *Dialector {
 	return d
 }
 
+// dialectorOf returns Dialector of db.
+func dialectorOf(db *gorm.DB) (Dialector, bool) {
+	switch d := db.Dialector.(type) {
+	case *Dialector:
+		return *d, true
+	case Dialector:
+		return d, true
+	default:
+		return Dialector{}, false
+	}
+}
+
 func (d Dialector) Name() string {
 	return "ydb"
 }
@@ -93,18 +139,40 @@ func (d Dialector) Initialize(db *gorm.DB) error {
 	defer cancel()
 
 	if d.Conn != nil {
-		db.ConnPool = d.Conn
+		switch d.Conn.(type) {
+		case gorm.TxBeginner, gorm.ConnPoolBeginner:
+			// sql.TxOptions and WithTxMode of transactions are mapped to ydb transaction modes
+			db.ConnPool = connPool{ConnPool: d.Conn}
+		default:
+			db.ConnPool = d.Conn
+		}
 	} else {
-		cc, err := ydb.Open(ctx, d.DSN, d.opts...)
+		opts := make([]ydb.Option, 0, len(d.opts)+1)
+		if d.queryStats {
+			opts = append(opts, ydb.With(config.WithGrpcOptions(statsGrpcOptions()...)))
+		}
+		opts = append(opts, d.opts...)
+		opts = append(opts, d.hooksOptions()...)
+		if d.driverLogger != nil {
+			opts = append(opts, d.driverLogger.options(db.Logger)...)
+		}
+
+		cc, err := ydb.Open(ctx, d.DSN, opts...)
 		if err != nil {
 			return xerrors.WithStacktrace(fmt.Errorf("connect error: %w", err))
 		}
 
-		c, err := ydb.Connector(cc,
+		connectorOpts := []ydb.ConnectorOption{
 			ydb.WithTablePathPrefix(d.tablePathPrefix),
-			ydb.WithAutoDeclare(),
 			ydb.WithNumericArgs(),
-		)
+			ydb.WithQueryService(d.queryService),
+		}
+		if !d.typedParams {
+			// typed parameters are declared by driver
+			connectorOpts = append(connectorOpts, ydb.WithAutoDeclare())
+		}
+
+		c, err := ydb.Connector(cc, connectorOpts...)
 		if err != nil {
 			return xerrors.WithStacktrace(fmt.Errorf("create connector error: %w", err))
 		}
@@ -115,19 +183,56 @@ func (d Dialector) Initialize(db *gorm.DB) error {
 		conn.SetMaxIdleConns(d.maxIdleConns)
 		conn.SetConnMaxIdleTime(d.connMaxIdleTime)
 
-		db.ConnPool = conn
+		db.ConnPool = connPool{ConnPool: conn}
 
 		db.DisableForeignKeyConstraintWhenMigrating = true
 		db.IgnoreRelationshipsWhenMigrating = true
 	}
 
+	for _, hook := range d.hooks {
+		// hooks get database/sql pool of pools over *sql.DB only
+		conn, _ := db.DB()
+		if err := hook.Initialize(conn); err != nil {
+			return xerrors.WithStacktrace(fmt.Errorf("initialize hook error: %w", err))
+		}
+	}
+
 	callbacks.RegisterDefaultCallbacks(db, &callbacks.Config{
-		CreateClauses:        []string{"INSERT", "VALUES"},
-		UpdateClauses:        []string{"UPDATE", "SET", "WHERE"},
-		DeleteClauses:        []string{"DELETE", "FROM", "WHERE"},
+		CreateClauses:        []string{"PRAGMA", "NAMED", "INSERT", "VALUES"},
+		QueryClauses:         []string{"PRAGMA", "NAMED", "SELECT", "FROM", "WHERE", "GROUP BY", "ORDER BY", "LIMIT", "FOR"},
+		UpdateClauses:        []string{"PRAGMA", "NAMED", "UPDATE", "SET", "WHERE"},
+		DeleteClauses:        []string{"PRAGMA", "NAMED", "DELETE", "FROM", "WHERE"},
 		LastInsertIDReversed: true,
 	})
 
+	if err := registerErrorCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := registerTxControlCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := d.registerPragmaCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := d.registerTruncatedCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := d.registerParamsCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := d.registerConsumedUnitsCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	if err := d.registerHooksCallbacks(db); err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
 	for k, v := range d.ClauseBuilders() {
 		db.ClauseBuilders[k] = v
 	}
@@ -135,8 +240,34 @@ func (d Dialector) Initialize(db *gorm.DB) error {
 	return nil
 }
 
+// statementCallbacks is names of gorm callbacks which are wrapped by callbacks of statement context.
+var statementCallbacks = map[Op][2]string{
+	OpCreate: {"gorm:begin_transaction", "gorm:commit_or_rollback_transaction"},
+	OpQuery:  {"gorm:query", "gorm:after_query"},
+	OpUpdate: {"gorm:begin_transaction", "gorm:commit_or_rollback_transaction"},
+	OpDelete: {"gorm:begin_transaction", "gorm:commit_or_rollback_transaction"},
+	OpRow:    {"gorm:row", "gorm:row"},
+	OpRaw:    {"gorm:raw", "gorm:raw"},
+}
+
+// statementAnchors returns names of callbacks which are wrapped by before and after callbacks of statement
+// context of operation op: callbacks of statement context registered earlier (get is Get of callback
+// processor) or gorm callbacks. Callbacks of statement context replace and restore context of statement,
+// so they must be nested.
+func statementAnchors(get func(name string) func(*gorm.DB), op Op) (before, after string) {
+	for _, name := range []string{"hooks", "consumed_units"} {
+		if get("ydb:before_"+name) != nil {
+			return "ydb:before_" + name, "ydb:after_" + name
+		}
+	}
+
+	return statementCallbacks[op][0], statementCallbacks[op][1]
+}
+
 func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 	return map[string]clause.ClauseBuilder{
+		"PRAGMA": d.buildPragmas,
+		"NAMED":  buildNamed,
 		"INSERT": func(c clause.Clause, builder clause.Builder) {
 			insert, ok := c.Expression.(clause.Insert)
 			if !ok {
@@ -172,10 +303,78 @@ func (d Dialector) ClauseBuilders() map[string]clause.ClauseBuilder {
 				stmt.WriteQuoted(insert.Table)
 			}
 		},
+		"WHERE": func(c clause.Clause, builder clause.Builder) {
+			where, ok := c.Expression.(clause.Where)
+			if !ok {
+				c.Build(builder)
+
+				return
+			}
+
+			stmt, ok := builder.(*gorm.Statement)
+			if !ok {
+				c.Build(builder)
+
+				return
+			}
+
+			exprs := listParams(stmt, where.Exprs)
+			if d.typedParams {
+				exprs = typedParams(stmt, exprs)
+			}
+
+			c.Expression = clause.Where{Exprs: exprs}
+			c.Build(builder)
+		},
+		"VALUES": func(c clause.Clause, builder clause.Builder) {
+			values, ok := c.Expression.(clause.Values)
+			if !ok || !d.typedParams {
+				c.Build(builder)
+
+				return
+			}
+
+			stmt, ok := builder.(*gorm.Statement)
+			if !ok {
+				c.Build(builder)
+
+				return
+			}
+
+			c.Expression = typedValues(stmt, values)
+			c.Build(builder)
+		},
+		"SET": func(c clause.Clause, builder clause.Builder) {
+			set, ok := c.Expression.(clause.Set)
+			if !ok || !d.typedParams {
+				c.Build(builder)
+
+				return
+			}
+
+			stmt, ok := builder.(*gorm.Statement)
+			if !ok {
+				c.Build(builder)
+
+				return
+			}
+
+			c.Expression = typedAssignments(stmt, set)
+			c.Build(builder)
+		},
+		"FROM": d.buildFrom,
+		"FOR": func(c clause.Clause, builder clause.Builder) {
+			buildLocking(d.lockingPolicy, c, builder)
+		},
 	}
 }
 
 func (d Dialector) Migrator(db *gorm.DB) gorm.Migrator {
+	stmt := db.Statement
+	if stmt != nil {
+		db = db.WithContext(withOp(stmt.Context, OpMigrate))
+	}
+
 	m := migrator.Migrator{
 		Config: migrator.Config{
 			DB:        db,
@@ -185,6 +384,7 @@ func (d Dialector) Migrator(db *gorm.DB) gorm.Migrator {
 
 	return Migrator{
 		Migrator:   m,
+		stmt:       stmt,
 		cacheStore: &sync.Map{},
 	}
 }
@@ -205,6 +405,13 @@ func (d Dialector) DefaultValueOf(_ *schema.Field) clause.Expression {
 }
 
 func (d Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, _ interface{}) {
+	if d.typedParams {
+		_, err := writer.WriteString(paramName(len(stmt.Vars) - 1))
+		checkAndAddError(stmt, err)
+
+		return
+	}
+
 	err := writer.WriteByte('$')
 	checkAndAddError(stmt, err)
 
@@ -213,6 +420,13 @@ func (d Dialector) BindVarTo(writer clause.Writer, stmt *gorm.Statement, _ inter
 }
 
 func (d Dialector) QuoteTo(writer clause.Writer, s string) {
+	// named expressions are not quoted
+	if isNamedExprName(s) {
+		_, _ = writer.WriteString(s)
+
+		return
+	}
+
 	var (
 		underQuoted, selfQuoted bool
 		continuousBacktick      int8
@@ -270,6 +484,8 @@ func (d Dialector) QuoteTo(writer clause.Writer, s string) {
 	}
 }
 
+// Explain returns sql with parameters replaced by YQL literals of vars (for example, `Timestamp("...")`,
+// `42ul` or `"..."u`), so logged statements can be executed with ydb CLI.
 func (d Dialector) Explain(sql string, vars ...interface{}) string {
-	return logger.ExplainSQL(sql, nil, `'`, vars...)
+	return explainYQL(sql, vars...)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 	"gorm.io/gorm"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
@@ -58,6 +59,14 @@ func TestWithConnMaxIdleTime(t *testing.T) {
 	require.Equal(t, connMaxIdleTime, d.connMaxIdleTime)
 }
 
+func TestWithQueryService(t *testing.T) {
+	d := &Dialector{}
+
+	WithQueryService()(d)
+
+	require.True(t, d.queryService)
+}
+
 func TestNew(t *testing.T) {
 	dsn := "dataSourceName"
 	tablePathPrefix := "tablePathPrefix"
@@ -189,6 +198,34 @@ func TestDialector_QuoteTo(t *testing.T) {
 			input:  "foo.bar.baz",
 			output: "`foo`.`bar`.`baz`",
 		},
+		{
+			input:  "$foo",
+			output: "$foo",
+		},
+		{
+			input:  "$_foo_1",
+			output: "$_foo_1",
+		},
+		{
+			input:  "$",
+			output: "`$`",
+		},
+		{
+			input:  "$1foo",
+			output: "`$1foo`",
+		},
+		{
+			input:  "$foo bar",
+			output: "`$foo bar`",
+		},
+		{
+			input:  "$foo.bar",
+			output: "`$foo`.`bar`",
+		},
+		{
+			input:  "$foo`; DROP TABLE bar",
+			output: "`$foo``; DROP TABLE bar`",
+		},
 	}
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
@@ -203,6 +240,9 @@ func TestDialector_QuoteTo(t *testing.T) {
 }
 
 func TestDialector_Explain(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	id := uint64(42)
+
 	tests := []struct {
 		sql      string
 		vars     []interface{}
@@ -214,7 +254,7 @@ func TestDialector_Explain(t *testing.T) {
 				"entryID",
 				"sometext",
 			},
-			expected: "INSERT INTO table (id, payload) VALUES ('entryID', 'sometext')",
+			expected: `INSERT INTO table (id, payload) VALUES ("entryID"u, "sometext"u)`,
 		},
 		{
 			sql: "INSERT INTO table (id, payload) VALUES (?, ?)",
@@ -222,7 +262,48 @@ func TestDialector_Explain(t *testing.T) {
 				"entryID",
 				123,
 			},
-			expected: "INSERT INTO table (id, payload) VALUES ('entryID', 123)",
+			expected: `INSERT INTO table (id, payload) VALUES ("entryID"u, 123l)`,
+		},
+		{
+			sql: "SELECT * FROM `products` WHERE id = $1 AND created_at > $2 AND payload = $3 AND price < $10",
+			vars: []interface{}{
+				&id, ts, []byte("bytes"), 4, 5, 6, 7, 8, 9, -1.5,
+			},
+			expected: "SELECT * FROM `products` WHERE id = 42ul AND created_at > Timestamp(\"2024-05-01T12:30:00.000000Z\")" +
+				" AND payload = \"bytes\" AND price < Double(\"-1.5\")",
+		},
+		{
+			sql: "UPSERT INTO `t` (a, b, c, d) VALUES ($1, $2, $3, $4)",
+			vars: []interface{}{
+				types.Int64Value(-7), int8(-1), (*string)(nil), types.OptionalValue(types.Uint32Value(3)),
+			},
+			expected: "UPSERT INTO `t` (a, b, c, d) VALUES (-7l, -1t, NULL, Just(3u))",
+		},
+		{
+			sql: "UPSERT INTO `t` (a, b, c, d, e, f) VALUES ($1, $2, $3, $4, $5, $6)",
+			vars: []interface{}{
+				types.OptionalValue(types.Int64Value(-7)),
+				types.NullValue(types.TypeInt16),
+				types.ListValue(types.Int8Value(-1), types.Int8Value(2)),
+				types.OptionalValue(types.StructValue(
+					types.StructFieldValue("id", types.Int64Value(-2)),
+					types.StructFieldValue("code", types.TextValue("D42")),
+				)),
+				types.TupleValue(types.Int16Value(-3), types.Uint8Value(4)),
+				types.DictValue(types.DictFieldValue(types.TextValue("k"), types.Int64Value(-5))),
+			},
+			expected: "UPSERT INTO `t` (a, b, c, d, e, f) VALUES (Just(-7l), Nothing(Optional<Int16>), [-1t,2t], " +
+				"Just(<|`code`:\"D42\"u,`id`:-2l|>), (-3s,4ut), {\"k\"u:-5l})",
+		},
+		{
+			sql:      "SELECT '$1', \"?\", `$1` FROM t WHERE a = $1 AND b = $2 AND c = $name",
+			vars:     []interface{}{true},
+			expected: "SELECT '$1', \"?\", `$1` FROM t WHERE a = true AND b = $2 AND c = $name",
+		},
+		{
+			sql:      "SELECT $p1, $p0, $p0x, $p2, $p FROM t",
+			vars:     []interface{}{1, "a"},
+			expected: "SELECT \"a\"u, 1l, $p0x, $p2, $p FROM t",
 		},
 	}
 	for _, tt := range tests {
@@ -1,6 +1,230 @@
 package dialect
 
-import "gorm.io/gorm"
+import (
+	"context"
+	"errors"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	grpcCodes "google.golang.org/grpc/codes"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrSchemeError is error of query over unknown or incompatible scheme objects (tables, columns, indexes).
+	ErrSchemeError = errors.New("ydb: scheme error")
+	// ErrOverloaded is error of overloaded database or exhausted resources.
+	ErrOverloaded = errors.New("ydb: overloaded")
+	// ErrTimeout is error of operation which was cancelled or not completed in time.
+	ErrTimeout = errors.New("ydb: timeout")
+)
+
+// issueCodeConstraintViolation is ydb issue code of constraint violations (for example, INSERT of existing key).
+const issueCodeConstraintViolation = 2012
+
+var _ gorm.ErrorTranslator = Dialector{}
+
+// Translate converts ydb errors to gorm errors (gorm.ErrDuplicatedKey) and driver errors (ErrSchemeError,
+// ErrOverloaded, ErrTimeout). Translated error wraps original error. Translate is called by gorm with
+// gorm.Config.TranslateError enabled.
+func (d Dialector) Translate(err error) error {
+	var translated *translatedError
+	if err == nil || errors.As(err, &translated) {
+		return err
+	}
+
+	switch {
+	case isConstraintViolation(err):
+		return &translatedError{kind: gorm.ErrDuplicatedKey, err: err}
+	case ydb.IsOperationErrorSchemeError(err):
+		return &translatedError{kind: ErrSchemeError, err: err}
+	case ydb.IsOperationErrorOverloaded(err),
+		ydb.IsTransportError(err, grpcCodes.ResourceExhausted):
+		return &translatedError{kind: ErrOverloaded, err: err}
+	case ydb.IsTimeoutError(err):
+		return &translatedError{kind: ErrTimeout, err: err}
+	default:
+		return err
+	}
+}
+
+func isConstraintViolation(err error) (violation bool) {
+	if !ydb.IsOperationError(err, Ydb.StatusIds_PRECONDITION_FAILED) {
+		return false
+	}
+
+	ydb.IterateByIssues(err, func(_ string, code Ydb.StatusIds_StatusCode, _ uint32) {
+		violation = violation || code == issueCodeConstraintViolation
+	})
+
+	return violation
+}
+
+// translatedError is error which matches to kind with errors.Is and unwraps to original error.
+type translatedError struct {
+	kind error
+	err  error
+}
+
+func (e *translatedError) Error() string {
+	return e.kind.Error() + ": " + e.err.Error()
+}
+
+func (e *translatedError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *translatedError) Unwrap() error {
+	return e.err
+}
+
+// Op is gorm operation which failed with Error.
+type Op string
+
+const (
+	OpCreate  Op = "create"
+	OpQuery   Op = "query"
+	OpUpdate  Op = "update"
+	OpDelete  Op = "delete"
+	OpRow     Op = "row"
+	OpRaw     Op = "raw"
+	OpMigrate Op = "migrate"
+)
+
+// Issue is ydb issue of failed operation.
+type Issue struct {
+	Code     uint32
+	Message  string
+	Severity uint32
+	Issues   []Issue
+}
+
+// Error is error of ydb operation executed by gorm. Use errors.As to get Error from gorm.DB.Error.
+type Error struct {
+	// Op is gorm operation.
+	Op Op
+	// Status is name of ydb operation status or transport code (for example, "operation/SCHEME_ERROR").
+	// Status is empty for errors without status.
+	Status string
+	// Code is code of ydb operation status or transport code.
+	Code int32
+	// Issues is tree of ydb issues.
+	Issues []Issue
+	// YQL is failed statement.
+	YQL string
+
+	err error
+}
+
+func (e *Error) Error() string {
+	return "ydb " + string(e.Op) + ": " + e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// Retryable reports whether operation may be retried without idempotency requirements (for example, on
+// transaction locks invalidation or overloads).
+func (e *Error) Retryable() bool {
+	return retry.Check(e.err).MustRetry(false)
+}
+
+func newError(op Op, yql string, err error) *Error {
+	e := &Error{
+		Op:  op,
+		YQL: yql,
+		err: err,
+	}
+
+	if opErr := ydb.OperationError(err); opErr != nil {
+		e.Status, e.Code = opErr.Name(), opErr.Code()
+	} else if transportErr := ydb.TransportError(err); transportErr != nil {
+		e.Status, e.Code = transportErr.Name(), transportErr.Code()
+	}
+
+	var withIssues interface {
+		Issues() []*Ydb_Issue.IssueMessage
+	}
+	if errors.As(err, &withIssues) {
+		e.Issues = issuesOf(withIssues.Issues())
+	}
+
+	return e
+}
+
+func issuesOf(messages []*Ydb_Issue.IssueMessage) []Issue {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	issues := make([]Issue, 0, len(messages))
+	for _, m := range messages {
+		issues = append(issues, Issue{
+			Code:     m.GetIssueCode(),
+			Message:  m.GetMessage(),
+			Severity: m.GetSeverity(),
+			Issues:   issuesOf(m.GetIssues()),
+		})
+	}
+
+	return issues
+}
+
+type ctxOpKey struct{}
+
+func withOp(ctx context.Context, op Op) context.Context {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return context.WithValue(ctx, ctxOpKey{}, op)
+}
+
+// errorCallback returns callback which wraps ydb errors of statement into Error. Operation from context
+// (for example, OpMigrate of Migrator queries) overrides op.
+func errorCallback(op Op) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		var e *Error
+		if db.Error == nil || !ydb.IsYdbError(db.Error) || errors.As(db.Error, &e) {
+			return
+		}
+
+		db.Error = newError(opOf(db, op), db.Statement.SQL.String(), db.Error)
+	}
+}
+
+// opOf returns operation of statement context (for example, OpMigrate of Migrator queries) or op.
+func opOf(db *gorm.DB, op Op) Op {
+	if db.Statement.Context != nil {
+		if ctxOp, ok := db.Statement.Context.Value(ctxOpKey{}).(Op); ok {
+			return ctxOp
+		}
+	}
+
+	return op
+}
+
+func registerErrorCallbacks(db *gorm.DB) error {
+	const name = "ydb:error"
+
+	for _, err := range []error{
+		db.Callback().Create().Register(name, errorCallback(OpCreate)),
+		db.Callback().Query().Register(name, errorCallback(OpQuery)),
+		db.Callback().Update().Register(name, errorCallback(OpUpdate)),
+		db.Callback().Delete().Register(name, errorCallback(OpDelete)),
+		db.Callback().Row().Register(name, errorCallback(OpRow)),
+		db.Callback().Raw().Register(name, errorCallback(OpRaw)),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
 func checkAndAddError(stmt *gorm.Statement, err error) {
 	if err != nil {
@@ -1,12 +1,21 @@
 package dialect
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Issue"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/operation"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
 )
 
 func Test_checkAndAddError(t *testing.T) {
@@ -50,3 +59,205 @@ func Test_checkAndAddError(t *testing.T) {
 		require.ErrorIs(t, stmt.Error, err)
 	})
 }
+
+// statusConn is grpc.ClientConnInterface which responds to operation service calls with status and issues.
+type statusConn struct {
+	status Ydb.StatusIds_StatusCode
+	issues []*Ydb_Issue.IssueMessage
+}
+
+func (c statusConn) Invoke(_ context.Context, _ string, _ interface{}, reply interface{}, _ ...grpc.CallOption) error {
+	response, ok := reply.(*Ydb_Operations.CancelOperationResponse)
+	if !ok {
+		return fmt.Errorf("unexpected reply %T", reply)
+	}
+
+	response.Status = c.status
+	response.Issues = c.issues
+
+	return nil
+}
+
+func (c statusConn) NewStream(
+	context.Context, *grpc.StreamDesc, string, ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	return nil, errors.New("not implemented")
+}
+
+// operationError returns ydb operation error with status code and issues.
+func operationError(t *testing.T, status Ydb.StatusIds_StatusCode, issues ...*Ydb_Issue.IssueMessage) error {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err := operation.New(ctx, statusConn{status: status, issues: issues}).Cancel(ctx, "")
+	require.True(t, ydb.IsOperationError(err, status))
+
+	return err
+}
+
+func TestDialector_Translate(t *testing.T) {
+	d := Dialector{}
+
+	for _, tt := range []struct {
+		name string
+		err  error
+		kind error
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			kind: nil,
+		},
+		{
+			name: "unknown",
+			err:  errors.New("unknown"),
+			kind: nil,
+		},
+		{
+			name: "duplicated key",
+			err: operationError(t, Ydb.StatusIds_PRECONDITION_FAILED, &Ydb_Issue.IssueMessage{
+				Message: "Execution",
+				Issues: []*Ydb_Issue.IssueMessage{{
+					Message:   "Conflict with existing key.",
+					IssueCode: issueCodeConstraintViolation,
+				}},
+			}),
+			kind: gorm.ErrDuplicatedKey,
+		},
+		{
+			name: "precondition failed",
+			err: operationError(t, Ydb.StatusIds_PRECONDITION_FAILED, &Ydb_Issue.IssueMessage{
+				Message: "Table is not empty",
+			}),
+			kind: nil,
+		},
+		{
+			name: "scheme error",
+			err: operationError(t, Ydb.StatusIds_SCHEME_ERROR, &Ydb_Issue.IssueMessage{
+				Message: "Cannot find table 'db.[/local/products]'",
+			}),
+			kind: ErrSchemeError,
+		},
+		{
+			name: "overloaded",
+			err:  operationError(t, Ydb.StatusIds_OVERLOADED),
+			kind: ErrOverloaded,
+		},
+		{
+			name: "timeout",
+			err:  operationError(t, Ydb.StatusIds_TIMEOUT),
+			kind: ErrTimeout,
+		},
+		{
+			name: "context deadline",
+			err:  fmt.Errorf("query: %w", context.DeadlineExceeded),
+			kind: ErrTimeout,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := d.Translate(tt.err)
+			if tt.kind == nil {
+				require.Equal(t, tt.err, err)
+
+				return
+			}
+
+			require.ErrorIs(t, err, tt.kind)
+			require.ErrorIs(t, err, tt.err)
+			require.Equal(t, err, d.Translate(err))
+
+			for _, kind := range []error{gorm.ErrDuplicatedKey, ErrSchemeError, ErrOverloaded, ErrTimeout} {
+				if kind != tt.kind {
+					require.NotErrorIs(t, err, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	open := func(t *testing.T, err error, translate bool) *gorm.DB {
+		t.Helper()
+
+		db, openErr := gorm.Open(&Dialector{Conn: &fakePool{err: err}}, &gorm.Config{
+			DisableAutomaticPing:   true,
+			SkipDefaultTransaction: true,
+			TranslateError:         translate,
+			Logger:                 logger.Discard,
+		})
+		require.NoError(t, openErr)
+
+		return db
+	}
+
+	t.Run("raw", func(t *testing.T) {
+		opErr := operationError(t, Ydb.StatusIds_PRECONDITION_FAILED, &Ydb_Issue.IssueMessage{
+			Message:  "Execution",
+			Severity: 1,
+			Issues: []*Ydb_Issue.IssueMessage{{
+				Message:   "Conflict with existing key.",
+				IssueCode: issueCodeConstraintViolation,
+				Severity:  1,
+			}},
+		})
+
+		err := open(t, opErr, true).Exec("INSERT INTO products (id) VALUES (1)").Error
+
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, OpRaw, e.Op)
+		require.Equal(t, "operation/PRECONDITION_FAILED", e.Status)
+		require.Equal(t, int32(Ydb.StatusIds_PRECONDITION_FAILED), e.Code)
+		require.Equal(t, "INSERT INTO products (id) VALUES (1)", e.YQL)
+		require.Equal(t, []Issue{{
+			Message:  "Execution",
+			Severity: 1,
+			Issues: []Issue{{
+				Code:     issueCodeConstraintViolation,
+				Message:  "Conflict with existing key.",
+				Severity: 1,
+			}},
+		}}, e.Issues)
+		require.False(t, e.Retryable())
+		require.ErrorIs(t, err, opErr)
+		require.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+	})
+
+	t.Run("create", func(t *testing.T) {
+		err := open(t, operationError(t, Ydb.StatusIds_OVERLOADED), false).Create(&Product{ID: 1}).Error
+
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, OpCreate, e.Op)
+		require.Equal(t, "operation/OVERLOADED", e.Status)
+		require.Equal(t, "UPSERT INTO `products` (`id`,`code`) VALUES ($1,$2)", e.YQL)
+		require.True(t, e.Retryable())
+	})
+
+	t.Run("migrate", func(t *testing.T) {
+		err := open(t, operationError(t, Ydb.StatusIds_SCHEME_ERROR), false).Migrator().DropColumn(&Product{}, "code")
+
+		var e *Error
+		require.ErrorAs(t, err, &e)
+		require.Equal(t, OpMigrate, e.Op)
+		require.Equal(t, "operation/SCHEME_ERROR", e.Status)
+		require.False(t, e.Retryable())
+	})
+
+	t.Run("not ydb error", func(t *testing.T) {
+		someErr := errors.New("some error")
+
+		err := open(t, someErr, false).Exec("SELECT 1").Error
+
+		var e *Error
+		require.False(t, errors.As(err, &e))
+		require.Equal(t, someErr, err)
+	})
+}
@@ -0,0 +1,115 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func dryRunDB(t *testing.T, opts ...Option) *gorm.DB {
+	d := New("", opts...)
+	d.Conn = &sql.DB{}
+
+	db, err := gorm.Open(d, &gorm.Config{
+		DryRun:                 true,
+		DisableAutomaticPing:   true,
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	return db
+}
+
+// fakePool is gorm.ConnPool which records queries with contexts, args and options of transactions. Queries fail with
+// errs in order and next queries fail with err.
+type fakePool struct {
+	gorm.ConnPool
+
+	contexts  []context.Context //nolint:containedctx
+	queries   []string
+	args      [][]interface{}
+	txOpts    []*sql.TxOptions
+	commits   int
+	rollbacks int
+	errs      []error
+	err       error
+	done      bool
+}
+
+func (p *fakePool) query(ctx context.Context, query string, args []interface{}) error {
+	p.contexts = append(p.contexts, ctx)
+	p.queries = append(p.queries, query)
+	p.args = append(p.args, args)
+
+	if len(p.errs) > 0 {
+		err := p.errs[0]
+		p.errs = p.errs[1:]
+
+		return err
+	}
+
+	return p.err
+}
+
+func (p *fakePool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, p.query(ctx, query, nil)
+}
+
+func (p *fakePool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	if err := p.query(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	return driverResult{}, nil
+}
+
+func (p *fakePool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return nil, p.query(ctx, query, args)
+}
+
+func (p *fakePool) BeginTx(_ context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	p.txOpts = append(p.txOpts, opts)
+	p.done = false
+
+	return (*fakeTx)(p), nil
+}
+
+// fakeTx is transaction of fakePool which counts commits and rollbacks.
+type fakeTx fakePool
+
+func (tx *fakeTx) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	return (*fakePool)(tx).ExecContext(ctx, query, args...)
+}
+
+func (tx *fakeTx) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	return (*fakePool)(tx).QueryContext(ctx, query, args...)
+}
+
+func (tx *fakeTx) Commit() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	tx.commits++
+
+	return nil
+}
+
+func (tx *fakeTx) Rollback() error {
+	if tx.done {
+		return sql.ErrTxDone
+	}
+	tx.done = true
+	tx.rollbacks++
+
+	return nil
+}
+
+type driverResult struct{}
+
+func (driverResult) LastInsertId() (int64, error) { return 0, nil }
+
+func (driverResult) RowsAffected() (int64, error) { return 1, nil }
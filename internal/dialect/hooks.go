@@ -0,0 +1,192 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"gorm.io/gorm"
+)
+
+// StatementHook is hook of gorm statements and ydb-go-sdk events of Dialector. Hooks are implemented by optional
+// modules of driver (OpenTelemetry tracing of github.com/ydb-platform/gorm-driver/otel and prometheus metrics
+// of github.com/ydb-platform/gorm-driver/metrics), so dependencies of hooks are not dependencies of driver.
+type StatementHook interface {
+	// Options returns ydb options of hook (for example, traces of ydb-go-sdk events). Options are not applied
+	// to Dialector with own Conn.
+	Options() []ydb.Option
+	// RetryTrace returns trace of retry loops of Retry and Transaction helpers.
+	RetryTrace() ydbTrace.Retry
+	// Initialize is called on Initialize of Dialector with database/sql pool of db (nil for pools of other types).
+	Initialize(conn *sql.DB) error
+	// BeforeStatement is called before each gorm statement with context of statement. It returns context of
+	// statement and function which is called after statement (or nil).
+	BeforeStatement(ctx context.Context, info StatementInfo) (context.Context, func(info StatementDoneInfo))
+}
+
+// StatementInfo is info of gorm statement before execution.
+type StatementInfo struct {
+	// Op is gorm operation of statement.
+	Op Op
+	// Table is table of statement (empty if table is not known before execution).
+	Table string
+}
+
+// StatementDoneInfo is info of executed gorm statement.
+type StatementDoneInfo struct {
+	// Table is table of statement.
+	Table string
+	// SQL is YQL of statement.
+	SQL string
+	// RowsAffected is count of rows affected by statement.
+	RowsAffected int64
+	// QueryMode is mode of ydb queries of statement: "data", "scan", "explain" and "scripting" for table
+	// service or "query" for query service. QueryMode is empty for statements without ydb queries and for
+	// Dialector with own Conn.
+	QueryMode string
+	// Error is error of statement.
+	Error error
+}
+
+// WithStatementHook apply hook of gorm statements and ydb-go-sdk events to Dialector. Hooks are called in
+// order of options before statements and in reverse order after statements.
+func WithStatementHook(hook StatementHook) Option {
+	return func(d *Dialector) {
+		if hook != nil {
+			d.hooks = append(d.hooks, hook)
+		}
+	}
+}
+
+// hooksOptions returns ydb options of hooks and options which detect query modes of statements.
+func (d Dialector) hooksOptions() []ydb.Option {
+	if len(d.hooks) == 0 {
+		return nil
+	}
+
+	opts := queryModeOptions(setHooksQueryMode)
+	for _, hook := range d.hooks {
+		opts = append(opts, hook.Options()...)
+	}
+
+	return opts
+}
+
+type ctxStatementHooksKey struct{}
+
+// statementHooks is hooks of statement with context of statement before hooks.
+type statementHooks struct {
+	ctx       context.Context //nolint:containedctx
+	done      []func(info StatementDoneInfo)
+	queryMode atomic.Value
+}
+
+// setHooksQueryMode sets query mode of ydb query to hooks of statement of context.
+func setHooksQueryMode(ctx context.Context, mode string) {
+	if s, ok := ctx.Value(ctxStatementHooksKey{}).(*statementHooks); ok {
+		s.queryMode.Store(mode)
+	}
+}
+
+const statementHooksKey = "ydb:statement_hooks"
+
+// beforeHooks returns callback which calls hooks before statement and replaces statement context with
+// context of hooks.
+func (d Dialector) beforeHooks(op Op) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		ctx := db.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		s := &statementHooks{ctx: ctx, done: make([]func(StatementDoneInfo), 0, len(d.hooks))}
+		db.InstanceSet(statementHooksKey, s)
+
+		info := StatementInfo{Op: opOf(db, op), Table: db.Statement.Table}
+
+		ctx = context.WithValue(ctx, ctxStatementHooksKey{}, s)
+		for _, hook := range d.hooks {
+			var done func(StatementDoneInfo)
+			if ctx, done = hook.BeforeStatement(ctx, info); done != nil {
+				s.done = append(s.done, done)
+			}
+		}
+
+		db.Statement.Context = ctx
+	}
+}
+
+// afterHooks restores statement context and calls hooks after statement in reverse order.
+func afterHooks(db *gorm.DB) {
+	v, ok := db.InstanceGet(statementHooksKey)
+	if !ok {
+		return
+	}
+
+	s, ok := v.(*statementHooks)
+	if !ok {
+		return
+	}
+
+	db.Statement.Context = s.ctx
+
+	mode, _ := s.queryMode.Load().(string)
+	info := StatementDoneInfo{
+		Table:        db.Statement.Table,
+		SQL:          db.Statement.SQL.String(),
+		RowsAffected: db.RowsAffected,
+		QueryMode:    mode,
+		Error:        db.Error,
+	}
+
+	for i := len(s.done) - 1; i >= 0; i-- {
+		s.done[i](info)
+	}
+}
+
+func (d Dialector) registerHooksCallbacks(db *gorm.DB) error {
+	if len(d.hooks) == 0 {
+		return nil
+	}
+
+	const (
+		before = "ydb:before_hooks"
+		after  = "ydb:after_hooks"
+	)
+
+	var (
+		create, query = db.Callback().Create(), db.Callback().Query()
+		update, del   = db.Callback().Update(), db.Callback().Delete()
+		row, raw      = db.Callback().Row(), db.Callback().Raw()
+
+		createBefore, createAfter = statementAnchors(create.Get, OpCreate)
+		queryBefore, queryAfter   = statementAnchors(query.Get, OpQuery)
+		updateBefore, updateAfter = statementAnchors(update.Get, OpUpdate)
+		deleteBefore, deleteAfter = statementAnchors(del.Get, OpDelete)
+		rowBefore, rowAfter       = statementAnchors(row.Get, OpRow)
+		rawBefore, rawAfter       = statementAnchors(raw.Get, OpRaw)
+	)
+
+	for _, err := range []error{
+		create.Before(createBefore).Register(before, d.beforeHooks(OpCreate)),
+		create.After(createAfter).Register(after, afterHooks),
+		query.Before(queryBefore).Register(before, d.beforeHooks(OpQuery)),
+		query.After(queryAfter).Register(after, afterHooks),
+		update.Before(updateBefore).Register(before, d.beforeHooks(OpUpdate)),
+		update.After(updateAfter).Register(after, afterHooks),
+		del.Before(deleteBefore).Register(before, d.beforeHooks(OpDelete)),
+		del.After(deleteAfter).Register(after, afterHooks),
+		row.Before(rowBefore).Register(before, d.beforeHooks(OpRow)),
+		row.After(rowAfter).Register(after, afterHooks),
+		raw.Before(rawBefore).Register(before, d.beforeHooks(OpRaw)),
+		raw.After(rawAfter).Register(after, afterHooks),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type ctxHookKey struct {
+	name string
+}
+
+// recordHook is StatementHook which records calls into events and marks contexts of statements with name.
+type recordHook struct {
+	name    string
+	events  *[]string
+	done    []StatementDoneInfo
+	retries int
+	err     error
+}
+
+func (h *recordHook) Options() []ydb.Option {
+	return nil
+}
+
+func (h *recordHook) RetryTrace() ydbTrace.Retry {
+	return ydbTrace.Retry{
+		OnRetry: func(ydbTrace.RetryLoopStartInfo) func(ydbTrace.RetryLoopDoneInfo) {
+			return func(info ydbTrace.RetryLoopDoneInfo) {
+				h.retries += info.Attempts
+			}
+		},
+	}
+}
+
+func (h *recordHook) Initialize(*sql.DB) error {
+	return h.err
+}
+
+func (h *recordHook) BeforeStatement(
+	ctx context.Context, info StatementInfo,
+) (context.Context, func(StatementDoneInfo)) {
+	*h.events = append(*h.events, "before "+h.name+" "+string(info.Op)+" "+info.Table)
+
+	return context.WithValue(ctx, ctxHookKey{h.name}, true), func(info StatementDoneInfo) {
+		*h.events = append(*h.events, "after "+h.name)
+		h.done = append(h.done, info)
+	}
+}
+
+func TestStatementHooks(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	var (
+		events   []string
+		reported int
+	)
+
+	first := &recordHook{name: "first", events: &events}
+	second := &recordHook{name: "second", events: &events}
+
+	queryErr := errors.New("query error")
+	d := New("",
+		WithStatementHook(first),
+		WithStatementHook(second),
+		WithConsumedUnitsHook(func(ctx context.Context, _ ConsumedUnits) {
+			require.Equal(t, true, ctx.Value(ctxHookKey{"first"}), "consumed units are not reported with context of hooks")
+			reported++
+		}),
+	)
+	d.Conn = &fakePool{err: queryErr}
+
+	db, err := gorm.Open(d, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	// query mode of statement is reported by ydb-go-sdk events of queries with context of statement
+	require.NoError(t, db.Callback().Query().After("gorm:query").Before("ydb:after_consumed_units").
+		Register("test:statement", func(db *gorm.DB) {
+			require.Equal(t, true, db.Statement.Context.Value(ctxHookKey{"first"}))
+			require.Equal(t, true, db.Statement.Context.Value(ctxHookKey{"second"}))
+
+			v, ok := db.InstanceGet(consumedUnitsKey)
+			require.True(t, ok)
+			v.(*unitsCounter).units = 1
+
+			setHooksQueryMode(db.Statement.Context, queryModeScan)
+		}))
+
+	ctx := context.Background()
+
+	tx := db.WithContext(ctx).Where("code = ?", "D42").Find(&[]Product{})
+	require.ErrorIs(t, tx.Error, queryErr)
+	require.Equal(t, ctx, tx.Statement.Context, "statement context not restored")
+	require.Equal(t, 1, reported)
+
+	require.Error(t, db.WithContext(ctx).Exec("SELECT 1").Error)
+
+	require.Equal(t, []string{
+		"before first query products", "before second query products", "after second", "after first",
+		"before first raw ", "before second raw ", "after second", "after first",
+	}, events)
+
+	require.Len(t, second.done, 2)
+	require.Equal(t, "products", second.done[0].Table)
+	require.Equal(t, "SELECT * FROM `products` WHERE code = $1", second.done[0].SQL)
+	require.Equal(t, queryModeScan, second.done[0].QueryMode)
+	require.ErrorIs(t, second.done[0].Error, queryErr)
+	require.Equal(t, "SELECT 1", second.done[1].SQL)
+	require.Empty(t, second.done[1].QueryMode)
+	require.Equal(t, first.done, second.done)
+
+	t.Run("Retry", func(t *testing.T) {
+		var calls int
+		err := Retry(db, func(*gorm.DB) error {
+			calls++
+			if calls < 3 {
+				return retry.RetryableError(errors.New("retryable error"))
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, first.retries)
+		require.Equal(t, 3, second.retries)
+	})
+
+	t.Run("Initialize", func(t *testing.T) {
+		initErr := errors.New("initialize error")
+
+		d := New("", WithStatementHook(&recordHook{events: &events, err: initErr}))
+		d.Conn = &fakePool{}
+
+		_, err := gorm.Open(d, &gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+		require.ErrorIs(t, err, initErr)
+	})
+}
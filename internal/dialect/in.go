@@ -0,0 +1,245 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// listParam is clause.Expression which binds ydb List value as single query parameter.
+type listParam struct {
+	value types.Value
+}
+
+func (p listParam) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		builder.AddVar(builder, p.value)
+
+		return
+	}
+
+	stmt.Vars = append(stmt.Vars, p.value)
+	stmt.DB.Dialector.BindVarTo(builder, stmt, p.value)
+}
+
+// inList is replacement of clause.IN which renders values as single List parameter: `column IN $N`.
+type inList struct {
+	column interface{}
+	values listParam
+}
+
+func (in inList) Build(builder clause.Builder) {
+	builder.WriteQuoted(in.column)
+	_, _ = builder.WriteString(" IN ")
+	in.values.Build(builder)
+}
+
+func (in inList) NegationBuild(builder clause.Builder) {
+	builder.WriteQuoted(in.column)
+	_, _ = builder.WriteString(" NOT IN ")
+	in.values.Build(builder)
+}
+
+// listParams replaces IN expressions with slices of scalar values with single List parameter.
+// Expressions which cannot be converted are returned as is.
+func listParams(stmt *gorm.Statement, exprs []clause.Expression) []clause.Expression {
+	result := make([]clause.Expression, 0, len(exprs))
+
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.IN:
+			if in, ok := inListOf(stmt, e); ok {
+				expr = in
+			}
+		case clause.Expr:
+			expr = exprWithListParams(e)
+		case clause.AndConditions:
+			expr = clause.AndConditions{Exprs: listParams(stmt, e.Exprs)}
+		case clause.OrConditions:
+			expr = clause.OrConditions{Exprs: listParams(stmt, e.Exprs)}
+		case clause.NotConditions:
+			expr = clause.NotConditions{Exprs: listParams(stmt, e.Exprs)}
+		}
+
+		result = append(result, expr)
+	}
+
+	return result
+}
+
+// inListOf converts clause.IN over single column to inList typed by column schema.Field if it is known.
+func inListOf(stmt *gorm.Statement, in clause.IN) (inList, bool) {
+	if len(in.Values) < 2 {
+		return inList{}, false
+	}
+
+	switch in.Column.(type) {
+	case clause.Column, string:
+	default:
+		return inList{}, false
+	}
+
+	value, err := toListValue(columnType(stmt, in.Column), in.Values)
+	if err != nil {
+		return inList{}, false
+	}
+
+	return inList{
+		column: in.Column,
+		values: listParam{value: value},
+	}, true
+}
+
+// exprWithListParams replaces slice vars placed right after `IN` keyword with List parameters.
+func exprWithListParams(expr clause.Expr) clause.Expr {
+	var (
+		vars []interface{}
+		idx  int
+	)
+
+	for i := 0; i < len(expr.SQL) && idx < len(expr.Vars); i++ {
+		if expr.SQL[i] != '?' {
+			continue
+		}
+
+		if rv := reflect.ValueOf(expr.Vars[idx]); isListCandidate(rv) && afterIn(expr.SQL[:i]) {
+			values := make([]interface{}, 0, rv.Len())
+			for j := 0; j < rv.Len(); j++ {
+				values = append(values, rv.Index(j).Interface())
+			}
+
+			if value, err := toListValue(nil, values); err == nil {
+				if vars == nil {
+					vars = append(make([]interface{}, 0, len(expr.Vars)), expr.Vars...)
+				}
+				vars[idx] = listParam{value: value}
+			}
+		}
+
+		idx++
+	}
+
+	if vars != nil {
+		expr.Vars = vars
+	}
+
+	return expr
+}
+
+func isListCandidate(rv reflect.Value) bool {
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return false
+	}
+
+	return rv.Len() > 0 && rv.Type().Elem().Kind() != reflect.Uint8
+}
+
+func afterIn(sql string) bool {
+	sql = strings.TrimRight(sql, " \t\n")
+	if len(sql) < 3 || !strings.EqualFold(sql[len(sql)-2:], "IN") {
+		return false
+	}
+
+	switch sql[len(sql)-3] {
+	case ' ', '\t', '\n', ')', '`':
+		return true
+	default:
+		return false
+	}
+}
+
+// columnType returns ydb Type (without Optional) of statement schema column or nil if column is unknown.
+func columnType(stmt *gorm.Statement, column interface{}) types.Type {
+	field := lookUpField(stmt, column)
+	if field == nil {
+		return nil
+	}
+
+	_, t, err := parseField(field)
+	if err != nil {
+		return nil
+	}
+
+	return t
+}
+
+// toListValue converts values to ydb List of t. Type of items inferred from first value if t is nil.
+func toListValue(t types.Type, values []interface{}) (types.Value, error) {
+	items := make([]types.Value, 0, len(values))
+
+	for _, v := range values {
+		if t == nil {
+			var err error
+			if t, err = typeOf(v); err != nil {
+				return nil, xerrors.WithStacktrace(err)
+			}
+		}
+
+		item, err := toValue(t, v)
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		items = append(items, item)
+	}
+
+	return types.ListValue(items...), nil
+}
+
+// typeOf infers ydb Type of go value v. Go int and uint are mapped to 64-bit ydb types.
+func typeOf(v interface{}) (types.Type, error) {
+	rv, err := indirect(v)
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	if !rv.IsValid() {
+		return nil, xerrors.WithStacktrace(errors.New("cannot infer type of nil value"))
+	}
+
+	if _, ok := rv.Interface().(time.Time); ok {
+		return types.TypeTimestamp, nil
+	}
+
+	switch rv.Kind() {
+	case reflect.Bool:
+		return types.TypeBool, nil
+	case reflect.Int8:
+		return types.TypeInt8, nil
+	case reflect.Int16:
+		return types.TypeInt16, nil
+	case reflect.Int32:
+		return types.TypeInt32, nil
+	case reflect.Int, reflect.Int64:
+		return types.TypeInt64, nil
+	case reflect.Uint8:
+		return types.TypeUint8, nil
+	case reflect.Uint16:
+		return types.TypeUint16, nil
+	case reflect.Uint32:
+		return types.TypeUint32, nil
+	case reflect.Uint, reflect.Uint64:
+		return types.TypeUint64, nil
+	case reflect.Float32:
+		return types.TypeFloat, nil
+	case reflect.Float64:
+		return types.TypeDouble, nil
+	case reflect.String:
+		return types.TypeText, nil
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return types.TypeBytes, nil
+		}
+	}
+
+	return nil, xerrors.WithStacktrace(fmt.Errorf("cannot infer ydb type of %s", rv.Type()))
+}
@@ -0,0 +1,175 @@
+package dialect
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestListParams(t *testing.T) { //nolint:funlen
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint
+	}
+
+	db := dryRunDB(t)
+
+	tests := []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+		vars []interface{}
+	}{
+		{
+			name: "primary keys",
+			tx:   db.Find(&[]Product{}, []int{1, 2, 3}),
+			sql:  "SELECT * FROM `products` WHERE `products`.`id` IN $1",
+			vars: []interface{}{
+				types.ListValue(types.Uint32Value(1), types.Uint32Value(2), types.Uint32Value(3)),
+			},
+		},
+		{
+			name: "IN clause",
+			tx: db.Where(clause.IN{
+				Column: clause.Column{Name: "code"},
+				Values: []interface{}{"a", "b"},
+			}).Find(&[]Product{}),
+			sql: "SELECT * FROM `products` WHERE `code` IN $1",
+			vars: []interface{}{
+				types.ListValue(types.TextValue("a"), types.TextValue("b")),
+			},
+		},
+		{
+			name: "NOT IN clause",
+			tx:   db.Not(map[string]interface{}{"code": []string{"a", "b"}}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE `code` NOT IN $1",
+			vars: []interface{}{
+				types.ListValue(types.TextValue("a"), types.TextValue("b")),
+			},
+		},
+		{
+			name: "IN expression",
+			tx:   db.Where("price > ? AND id IN ?", 10, []uint64{1, 2}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE price > $1 AND id IN $2",
+			vars: []interface{}{
+				10,
+				types.ListValue(types.Uint64Value(1), types.Uint64Value(2)),
+			},
+		},
+		{
+			name: "nested conditions",
+			tx:   db.Where(db.Where("code IN ?", []string{"a"}).Or("price = ?", 1)).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE code IN $1 OR price = $2",
+			vars: []interface{}{
+				types.ListValue(types.TextValue("a")),
+				1,
+			},
+		},
+		{
+			name: "parenthesized IN expression",
+			tx:   db.Where("id IN (?)", []int{1, 2}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE id IN ($1,$2)",
+			vars: []interface{}{1, 2},
+		},
+		{
+			name: "single value",
+			tx:   db.Find(&[]Product{}, []int{1}),
+			sql:  "SELECT * FROM `products` WHERE `products`.`id` = $1",
+			vars: []interface{}{1},
+		},
+		{
+			name: "IN clause of statement table",
+			tx: db.Where(clause.IN{
+				Column: clause.Column{Table: clause.CurrentTable, Name: "price"},
+				Values: []interface{}{1, 2},
+			}).Find(&[]Product{}),
+			sql: "SELECT * FROM `products` WHERE `products`.`price` IN $1",
+			vars: []interface{}{
+				types.ListValue(types.Uint64Value(1), types.Uint64Value(2)),
+			},
+		},
+		{
+			name: "IN clause of joined table",
+			tx: db.Joins("JOIN orders ON orders.product_id = products.id").Where(clause.IN{
+				Column: clause.Column{Table: "orders", Name: "price"},
+				Values: []interface{}{1, 2},
+			}).Find(&[]Product{}),
+			sql: "SELECT `products`.`id`,`products`.`code`,`products`.`price` FROM `products` " +
+				"JOIN orders ON orders.product_id = products.id WHERE `orders`.`price` IN $1",
+			vars: []interface{}{
+				types.ListValue(types.Int64Value(1), types.Int64Value(2)),
+			},
+		},
+		{
+			name: "values with nil",
+			tx: db.Where(clause.IN{
+				Column: clause.Column{Name: "code"},
+				Values: []interface{}{"a", nil},
+			}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE `code` IN ($1,$2)",
+			vars: []interface{}{"a", nil},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+			require.Equal(t, tt.vars, tt.tx.Statement.Vars)
+		})
+	}
+}
+
+func Test_afterIn(t *testing.T) {
+	tests := []struct {
+		sql      string
+		expected bool
+	}{
+		{sql: "id IN ", expected: true},
+		{sql: "id in", expected: true},
+		{sql: "`id` IN ", expected: true},
+		{sql: "(a, b) IN ", expected: true},
+		{sql: "id NOT IN ", expected: true},
+		{sql: "JOIN ", expected: false},
+		{sql: "id = ", expected: false},
+		{sql: "IN ", expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sql, func(t *testing.T) {
+			require.Equal(t, tt.expected, afterIn(tt.sql))
+		})
+	}
+}
+
+func Test_typeOf(t *testing.T) {
+	tests := []struct {
+		value     interface{}
+		typesType types.Type
+		isError   bool
+	}{
+		{value: 1, typesType: types.TypeInt64},
+		{value: uint(1), typesType: types.TypeUint64},
+		{value: int16(1), typesType: types.TypeInt16},
+		{value: "text", typesType: types.TypeText},
+		{value: []byte("bytes"), typesType: types.TypeBytes},
+		{value: sql.NullString{String: "text", Valid: true}, typesType: types.TypeText},
+		{value: nil, isError: true},
+		{value: struct{}{}, isError: true},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			typesType, err := typeOf(tt.value)
+			if tt.isError {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.typesType, typesType)
+		})
+	}
+}
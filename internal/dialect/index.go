@@ -0,0 +1,141 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// ErrIndexNotFound is error of query with index hint of unknown secondary index.
+var ErrIndexNotFound = errors.New("ydb: secondary index not found")
+
+// indexHint is clause of secondary index for reading of statement table: `FROM table VIEW index`.
+type indexHint struct {
+	name string
+}
+
+// UseIndex returns clause which makes query read statement table with secondary index name.
+// Name of index may be index name or name of index from model schema.
+func UseIndex(name string) clause.Expression {
+	return indexHint{name: name}
+}
+
+func (h indexHint) Name() string {
+	return "VIEW"
+}
+
+func (h indexHint) Build(builder clause.Builder) {
+	builder.WriteQuoted(h.name)
+}
+
+func (h indexHint) MergeClause(c *clause.Clause) {
+	c.Expression = h
+}
+
+// buildFrom builds FROM clause with secondary index of statement table from index hint.
+func (d Dialector) buildFrom(c clause.Clause, builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		c.Build(builder)
+
+		return
+	}
+
+	hint, ok := stmt.Clauses["VIEW"].Expression.(indexHint)
+	if !ok {
+		c.Build(builder)
+
+		return
+	}
+
+	from, ok := c.Expression.(clause.From)
+	if !ok {
+		from = clause.From{}
+	}
+
+	if len(from.Tables) > 1 {
+		checkAndAddError(stmt, xerrors.WithStacktrace(
+			fmt.Errorf("index hint `%s` is not allowed for many tables", hint.name),
+		))
+
+		return
+	}
+
+	table := clause.Table{Name: clause.CurrentTable}
+	if len(from.Tables) == 1 {
+		table = from.Tables[0]
+	}
+
+	name, err := d.lookUpIndex(stmt, hint.name)
+	checkAndAddError(stmt, err)
+
+	_, err = stmt.WriteString("FROM ")
+	checkAndAddError(stmt, err)
+
+	stmt.WriteQuoted(clause.Table{Name: table.Name, Raw: table.Raw})
+
+	_, err = stmt.WriteString(" VIEW ")
+	checkAndAddError(stmt, err)
+
+	stmt.WriteQuoted(name)
+
+	if table.Alias != "" {
+		_, err = stmt.WriteString(" AS ")
+		checkAndAddError(stmt, err)
+
+		stmt.WriteQuoted(table.Alias)
+	}
+
+	for _, join := range from.Joins {
+		err = stmt.WriteByte(' ')
+		checkAndAddError(stmt, err)
+
+		join.Build(stmt)
+	}
+}
+
+// lookUpIndex returns name of secondary index of statement table by name of index or name of schema index.
+// Index existence is checked with Migrator.HasIndex, checked indexes are cached until indexes or table are
+// changed by Migrator.
+func (d Dialector) lookUpIndex(stmt *gorm.Statement, name string) (string, error) {
+	if stmt.Schema != nil {
+		if idx := stmt.Schema.LookIndex(name); idx != nil {
+			name = idx.Name
+		}
+	}
+
+	if stmt.DB.DryRun {
+		return name, nil
+	}
+
+	key := stmt.Table + "/" + name
+	if d.indexes != nil {
+		if _, has := d.indexes.Load(key); has {
+			return name, nil
+		}
+	}
+
+	m, ok := stmt.DB.Session(&gorm.Session{NewDB: true}).Migrator().(Migrator)
+	if !ok {
+		return name, xerrors.WithStacktrace(errors.New("error conversion to Migrator"))
+	}
+
+	exists, err := m.hasIndex(stmt.Table, name)
+	if err != nil {
+		return name, xerrors.WithStacktrace(fmt.Errorf("check index `%s` of table `%s`: %w", name, stmt.Table, err))
+	}
+
+	if !exists {
+		return name, xerrors.WithStacktrace(fmt.Errorf("%w: `%s` of table `%s`", ErrIndexNotFound, name, stmt.Table))
+	}
+
+	if d.indexes != nil {
+		d.indexes.Store(key, struct{}{})
+	}
+
+	return name, nil
+}
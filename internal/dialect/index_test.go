@@ -0,0 +1,135 @@
+package dialect
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+func TestUseIndex(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint32 `gorm:"index:idx_products_price"`
+	}
+
+	db := dryRunDB(t)
+
+	for _, tt := range []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+	}{
+		{
+			name: "index name",
+			tx:   db.Clauses(UseIndex("idx_products_price")).Where("price > ?", 100).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` VIEW `idx_products_price` WHERE price > $1",
+		},
+		{
+			name: "schema index",
+			tx:   db.Clauses(UseIndex("Price")).First(&Product{}, "price = ?", 100),
+			sql:  "SELECT * FROM `products` VIEW `idx_products_price` WHERE price = $1 ORDER BY `products`.`id` LIMIT $2",
+		},
+		{
+			name: "alias",
+			tx: db.Model(&Product{}).
+				Clauses(clause.From{Tables: []clause.Table{{Name: "products", Alias: "p"}}}, UseIndex("Price")).
+				Select("p.code").
+				Find(&[]string{}),
+			sql: "SELECT p.code FROM `products` VIEW `idx_products_price` AS `p`",
+		},
+		{
+			name: "without hint",
+			tx:   db.Where("price > ?", 100).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE price > $1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+		})
+	}
+
+	t.Run("many tables", func(t *testing.T) {
+		tx := db.Clauses(
+			clause.From{Tables: []clause.Table{{Name: "products"}, {Name: "orders"}}},
+			UseIndex("idx_products_price"),
+		).Find(&[]Product{})
+		require.Error(t, tx.Error)
+	})
+}
+
+func TestUseIndex_Cache(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Price uint32 `gorm:"index:idx_products_price"`
+	}
+
+	d := New("")
+	d.Conn = &fakePool{err: errors.New("exec error")}
+
+	db, err := gorm.Open(d, &gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	t.Run("lookup error", func(t *testing.T) {
+		tx := db.Clauses(UseIndex("Price")).Find(&[]Product{})
+		require.Error(t, tx.Error)
+		require.NotErrorIs(t, tx.Error, ErrIndexNotFound)
+		require.ErrorContains(t, tx.Error, "check index `idx_products_price` of table `products`")
+	})
+
+	cached := func() (keys []string) {
+		d.indexes.Range(func(key, _ any) bool {
+			keys = append(keys, key.(string)) //nolint:forcetypeassert
+
+			return true
+		})
+		sort.Strings(keys)
+
+		return keys
+	}
+
+	for _, tt := range []struct {
+		name    string
+		migrate func(m gorm.Migrator) error
+	}{
+		{
+			name: "CreateIndex",
+			migrate: func(m gorm.Migrator) error {
+				return m.CreateIndex(&Product{}, "idx_products_price")
+			},
+		},
+		{
+			name: "DropIndex",
+			migrate: func(m gorm.Migrator) error {
+				return m.DropIndex(&Product{}, "idx_products_price")
+			},
+		},
+		{
+			name: "RenameIndex",
+			migrate: func(m gorm.Migrator) error {
+				return m.RenameIndex(&Product{}, "idx_products_price", "idx_price")
+			},
+		},
+		{
+			name: "DropTable",
+			migrate: func(m gorm.Migrator) error {
+				return m.DropTable("products")
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			d.indexes.Store("products/idx_products_price", struct{}{})
+			d.indexes.Store("orders/idx_orders_price", struct{}{})
+
+			_ = tt.migrate(db.Migrator())
+
+			require.Equal(t, []string{"orders/idx_orders_price"}, cached())
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package dialect
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+)
+
+// explainYQL replaces parameters of sql (`$N`, `$pN` and `?`) with YQL literals of vars. Parameters inside of string
+// literals and quoted identifiers are not replaced.
+func explainYQL(sql string, vars ...interface{}) string {
+	var (
+		b     strings.Builder
+		quote byte
+		next  int
+	)
+
+	b.Grow(len(sql))
+
+	for i := 0; i < len(sql); i++ {
+		c := sql[i]
+
+		switch {
+		case quote != 0:
+			b.WriteByte(c)
+
+			if c == '\\' && quote != '`' && i+1 < len(sql) {
+				i++
+				b.WriteByte(sql[i])
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+			b.WriteByte(c)
+		case c == '?' && next < len(vars):
+			b.WriteString(yqlLiteral(vars[next]))
+			next++
+		case c == '$':
+			n, j := paramIndex(sql, i)
+			if n < 0 || n >= len(vars) {
+				b.WriteString(sql[i:j])
+			} else {
+				b.WriteString(yqlLiteral(vars[n]))
+			}
+
+			i = j - 1
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
+
+// paramIndex returns index of var of query parameter at sql[i] and end of parameter: numeric parameters
+// ($1, $2, ...) and typed parameters ($p0, $p1, ...) are parameters. Index is -1 if sql[i] is not parameter.
+func paramIndex(sql string, i int) (index, end int) {
+	start, offset := i+1, 1
+	if start < len(sql) && sql[start] == 'p' {
+		start, offset = start+1, 0
+	}
+
+	end = start
+	for end < len(sql) && isDigit(sql[end]) {
+		end++
+	}
+
+	// $p0x is name of named expression
+	if end == start || (offset == 0 && end < len(sql) && isIdentChar(sql[end])) {
+		return -1, i + 1
+	}
+
+	n, err := strconv.Atoi(sql[start:end])
+	if err != nil {
+		return -1, end
+	}
+
+	return n - offset, end
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// yqlLiteral returns YQL literal of query parameter v: ydb values are rendered as typed literals
+// (for example, `Timestamp("...")`, `42ul` or `"..."u`), and go values as literals of inferred ydb types.
+func yqlLiteral(v interface{}) string {
+	switch x := v.(type) {
+	case types.Value:
+		return valueYQL(x)
+	case sql.NamedArg:
+		return yqlLiteral(x.Value)
+	case time.Duration:
+		return types.IntervalValueFromDuration(x).Yql()
+	}
+
+	rv, err := indirect(v)
+	if err != nil {
+		return yqlString(fmt.Sprint(v))
+	}
+
+	if !rv.IsValid() {
+		return "NULL"
+	}
+
+	t, err := typeOf(rv.Interface())
+	if err != nil {
+		return yqlString(fmt.Sprint(rv.Interface()))
+	}
+
+	value, err := toValue(t, rv.Interface())
+	if err != nil {
+		return yqlString(fmt.Sprint(rv.Interface()))
+	}
+
+	return valueYQL(value)
+}
+
+// valueYQL returns YQL literal of ydb value. Signed integers are rendered by driver, because ydb-go-sdk
+// renders negative Int8, Int16 and Int64 values as unsigned, so lists, tuples, structs, dicts and optionals
+// are rendered by driver too. Other values (and sets) are rendered by ydb-go-sdk.
+func valueYQL(v types.Value) string {
+	if suffix, ok := signedSuffix(v.Type()); ok {
+		var s string
+		if err := types.CastTo(v, &s); err != nil {
+			return v.Yql()
+		}
+
+		return s + suffix
+	}
+
+	switch x := v.(type) {
+	case interface{ ListItems() []types.Value }:
+		return "[" + valuesYQL(x.ListItems()) + "]"
+	case interface{ TupleItems() []types.Value }:
+		return "(" + valuesYQL(x.TupleItems()) + ")"
+	case interface{ StructFields() map[string]types.Value }:
+		fields := x.StructFields()
+
+		names := make([]string, 0, len(fields))
+		for name := range fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		items := make([]string, 0, len(names))
+		for _, name := range names {
+			items = append(items, "`"+name+"`:"+valueYQL(fields[name]))
+		}
+
+		return "<|" + strings.Join(items, ",") + "|>"
+	case interface {
+		DictValues() map[types.Value]types.Value
+	}:
+		items := make([]string, 0, len(x.DictValues()))
+		for k, v := range x.DictValues() {
+			items = append(items, valueYQL(k)+":"+valueYQL(v))
+		}
+		sort.Strings(items)
+
+		return "{" + strings.Join(items, ",") + "}"
+	}
+
+	if isOptional, inner := types.IsOptional(v.Type()); isOptional {
+		return optionalYQL(v, inner)
+	}
+
+	return v.Yql()
+}
+
+// signedSuffix returns suffix of YQL literal of signed integer type t.
+func signedSuffix(t types.Type) (string, bool) {
+	switch t {
+	case types.TypeInt8:
+		return "t", true
+	case types.TypeInt16:
+		return "s", true
+	case types.TypeInt64:
+		return "l", true
+	default:
+		return "", false
+	}
+}
+
+// valuesYQL returns comma separated YQL literals of values.
+func valuesYQL(values []types.Value) string {
+	items := make([]string, 0, len(values))
+	for _, v := range values {
+		items = append(items, valueYQL(v))
+	}
+
+	return strings.Join(items, ",")
+}
+
+// optionalYQL returns YQL literal `Just(...)` of optional value v with inner type. Empty optionals and
+// optionals of optionals are rendered by ydb-go-sdk, because ydb-go-sdk has no getter of optional value.
+func optionalYQL(v types.Value, inner types.Type) string {
+	if suffix, ok := signedSuffix(inner); ok {
+		var s string
+		if err := types.CastTo(v, &s); err != nil || s == "" {
+			return v.Yql()
+		}
+
+		return "Just(" + s + suffix + ")"
+	}
+
+	// optional casts to driver.Value of its value, values of lists, tuples, structs and dicts are ydb values
+	var dv driver.Value
+	if err := types.CastTo(v, &dv); err != nil {
+		return v.Yql()
+	}
+
+	if value, ok := dv.(types.Value); ok && types.Equal(value.Type(), inner) {
+		return "Just(" + valueYQL(value) + ")"
+	}
+
+	return v.Yql()
+}
+
+// yqlString returns YQL string literal of s: quotes, backslashes and control characters are escaped with YQL
+// escape sequences, other characters (including UTF-8 sequences) are written as is.
+func yqlString(s string) string {
+	var b strings.Builder
+
+	b.Grow(len(s) + 2)
+	b.WriteByte('"')
+
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"', '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if c < 0x20 || c == 0x7f {
+				fmt.Fprintf(&b, `\x%02X`, c)
+			} else {
+				b.WriteByte(c)
+			}
+		}
+	}
+
+	b.WriteByte('"')
+
+	return b.String()
+}
@@ -0,0 +1,83 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// ErrLockingNotSupported is error of query with locking clause (`FOR UPDATE`, `FOR SHARE`) with LockingError policy.
+var ErrLockingNotSupported = errors.New("ydb: locking clauses are not supported")
+
+// LockingPolicy defines behavior of queries with locking clause (clause.Locking). YDB has no explicit row
+// locks: serializable transactions check read rows with optimistic locks and abort on conflicts.
+type LockingPolicy int
+
+const (
+	// LockingError fails queries with locking clause before execution with ErrLockingNotSupported.
+	// It is default policy.
+	LockingError LockingPolicy = iota
+	// LockingIgnore drops locking clause from queries.
+	LockingIgnore
+	// LockingWarn drops locking clause from queries and logs warning with gorm logger.
+	LockingWarn
+)
+
+// WithLockingPolicy apply policy of locking clauses to Dialector.
+func WithLockingPolicy(policy LockingPolicy) Option {
+	return func(d *Dialector) {
+		d.lockingPolicy = policy
+	}
+}
+
+// buildLocking builds locking clause ("FOR") with policy.
+func buildLocking(policy LockingPolicy, c clause.Clause, builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		c.Build(builder)
+
+		return
+	}
+
+	locking := lockingOf(c)
+
+	switch policy {
+	case LockingIgnore:
+	case LockingWarn:
+		stmt.DB.Logger.Warn(stmt.Context,
+			"ydb: %s clause is ignored, ydb uses optimistic locks of serializable transactions", locking,
+		)
+	default:
+		checkAndAddError(stmt, xerrors.WithStacktrace(fmt.Errorf("%w: %s", ErrLockingNotSupported, locking)))
+
+		return
+	}
+
+	dropSeparator(stmt)
+}
+
+// dropSeparator removes separator of clause which is written by gorm before dropped clause.
+func dropSeparator(stmt *gorm.Statement) {
+	sql := stmt.SQL.String()
+	if !strings.HasSuffix(sql, " ") {
+		return
+	}
+
+	stmt.SQL.Reset()
+	_, err := stmt.SQL.WriteString(sql[:len(sql)-1])
+	checkAndAddError(stmt, err)
+}
+
+// lockingOf returns text of locking clause for messages.
+func lockingOf(c clause.Clause) string {
+	if locking, ok := c.Expression.(clause.Locking); ok && locking.Strength != "" {
+		return "FOR " + locking.Strength
+	}
+
+	return "FOR"
+}
@@ -0,0 +1,56 @@
+package dialect
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+// recordWriter is logger.Writer which records messages.
+type recordWriter struct {
+	messages []string
+}
+
+func (w *recordWriter) Printf(format string, args ...interface{}) {
+	w.messages = append(w.messages, fmt.Sprintf(format, args...))
+}
+
+func TestLockingPolicy(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	query := func(db *gorm.DB) *gorm.DB {
+		return db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).Find(&[]Product{})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		tx := query(dryRunDB(t))
+		require.ErrorIs(t, tx.Error, ErrLockingNotSupported)
+		require.ErrorContains(t, tx.Error, "FOR UPDATE")
+	})
+
+	t.Run("ignore", func(t *testing.T) {
+		tx := query(dryRunDB(t, WithLockingPolicy(LockingIgnore)))
+		require.NoError(t, tx.Error)
+		require.Equal(t, "SELECT * FROM `products`", tx.Statement.SQL.String())
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		w := &recordWriter{}
+
+		db := dryRunDB(t, WithLockingPolicy(LockingWarn))
+		db.Logger = logger.New(w, logger.Config{LogLevel: logger.Warn})
+
+		tx := query(db)
+		require.NoError(t, tx.Error)
+		require.Equal(t, "SELECT * FROM `products`", tx.Statement.SQL.String())
+		require.Len(t, w.messages, 1)
+		require.Contains(t, w.messages[0], "FOR UPDATE clause is ignored")
+	})
+}
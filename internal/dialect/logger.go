@@ -0,0 +1,144 @@
+package dialect
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"gorm.io/gorm/logger"
+)
+
+// DriverLoggerOption is option for WithDriverLogger.
+type DriverLoggerOption func(l *driverLogger)
+
+// defaultDriverLogDetails is ydb-go-sdk events which are logged by default: connections and balancing of
+// driver, discovery, sessions (with queries and transactions of sessions) and retries.
+const defaultDriverLogDetails = ydbTrace.DriverEvents |
+	ydbTrace.DiscoveryEvents |
+	ydbTrace.TableSessionEvents |
+	ydbTrace.TablePoolAPIEvents |
+	ydbTrace.QuerySessionEvents |
+	ydbTrace.RetryEvents
+
+// WithDriverLogLevel sets minimal level of ydb-go-sdk events which are forwarded to gorm logger. Default is
+// log.INFO. Successful session and query events are logged by ydb-go-sdk with log.TRACE and log.DEBUG levels.
+func WithDriverLogLevel(level log.Level) DriverLoggerOption {
+	return func(l *driverLogger) {
+		l.level = level
+	}
+}
+
+// WithDriverLogDetails sets ydb-go-sdk events which are forwarded to gorm logger. Default is driver, discovery,
+// session and retry events.
+func WithDriverLogDetails(details ydbTrace.Details) DriverLoggerOption {
+	return func(l *driverLogger) {
+		l.details = details
+	}
+}
+
+// WithDriverSlowThreshold sets threshold of slow ydb-go-sdk events (for example, queries, creating of sessions
+// or discovery): events which are longer than threshold are logged as warnings regardless of level of event.
+// Zero threshold (default) disables logging of slow events.
+func WithDriverSlowThreshold(threshold time.Duration) DriverLoggerOption {
+	return func(l *driverLogger) {
+		l.slowThreshold = threshold
+	}
+}
+
+// WithDriverLogger apply forwarding of ydb-go-sdk log events to gorm logger of db (gorm.Config.Logger), so
+// driver events (discovery, creating and deleting of sessions, transport errors and others) are logged with
+// gorm statements. Errors and fatal events of ydb-go-sdk are logged with Error, warnings with Warn and other
+// events with Info of gorm logger, so gorm log level applies to both. WithDriverLogger has no effect for
+// Dialector with own Conn.
+func WithDriverLogger(opts ...DriverLoggerOption) Option {
+	return func(d *Dialector) {
+		l := &driverLogger{
+			level:   log.INFO,
+			details: defaultDriverLogDetails,
+		}
+
+		for _, opt := range opts {
+			if opt != nil {
+				opt(l)
+			}
+		}
+
+		d.driverLogger = l
+	}
+}
+
+var _ log.Logger = (*driverLogger)(nil)
+
+// driverLogger is ydb-go-sdk logger over gorm logger.
+type driverLogger struct {
+	logger        logger.Interface
+	level         log.Level
+	details       ydbTrace.Details
+	slowThreshold time.Duration
+}
+
+// options returns ydb options which log ydb-go-sdk events with gorm logger gl.
+func (l *driverLogger) options(gl logger.Interface) []ydb.Option {
+	dl := *l
+	dl.logger = gl
+
+	return []ydb.Option{
+		ydb.WithTraceDriver(log.Driver(&dl, dl.details)),
+		ydb.WithTraceDiscovery(log.Discovery(&dl, dl.details)),
+		ydb.WithTraceTable(log.Table(&dl, dl.details)),
+		ydb.WithTraceQuery(log.Query(&dl, dl.details)),
+		ydb.WithTraceRetry(log.Retry(&dl, dl.details)),
+		ydb.WithTraceDatabaseSQL(log.DatabaseSQL(&dl, dl.details)),
+	}
+}
+
+// Log forwards ydb-go-sdk event to gorm logger.
+func (l *driverLogger) Log(ctx context.Context, msg string, fields ...log.Field) {
+	level := log.LevelFromContext(ctx)
+
+	slow := l.slowThreshold > 0 && latencyOf(fields) >= l.slowThreshold
+	if slow && level < log.WARN {
+		level = log.WARN
+	}
+
+	if level < l.level || level >= log.QUIET {
+		return
+	}
+
+	var b strings.Builder
+	if slow {
+		fmt.Fprintf(&b, "SLOW YDB EVENT >= %v ", l.slowThreshold)
+	}
+
+	b.WriteString(strings.Join(log.NamesFromContext(ctx), "."))
+	b.WriteString(": ")
+	b.WriteString(msg)
+
+	for _, field := range fields {
+		fmt.Fprintf(&b, " %s=%s", field.Key(), field.String())
+	}
+
+	switch {
+	case level >= log.ERROR:
+		l.logger.Error(ctx, "%s", b.String())
+	case level == log.WARN:
+		l.logger.Warn(ctx, "%s", b.String())
+	default:
+		l.logger.Info(ctx, "%s", b.String())
+	}
+}
+
+// latencyOf returns latency of ydb-go-sdk event or zero for events without latency.
+func latencyOf(fields []log.Field) time.Duration {
+	for _, field := range fields {
+		if field.Key() == "latency" && field.Type() == log.DurationType {
+			return field.DurationValue()
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,97 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/log"
+	"gorm.io/gorm/logger"
+)
+
+// recordLogger is gorm logger which records messages with levels.
+type recordLogger struct {
+	logger.Interface
+
+	messages []string
+}
+
+func (l *recordLogger) Info(_ context.Context, msg string, data ...interface{}) {
+	l.messages = append(l.messages, "info: "+fmt.Sprintf(msg, data...))
+}
+
+func (l *recordLogger) Warn(_ context.Context, msg string, data ...interface{}) {
+	l.messages = append(l.messages, "warn: "+fmt.Sprintf(msg, data...))
+}
+
+func (l *recordLogger) Error(_ context.Context, msg string, data ...interface{}) {
+	l.messages = append(l.messages, "error: "+fmt.Sprintf(msg, data...))
+}
+
+func TestDriverLogger(t *testing.T) {
+	event := func(level log.Level, names ...string) context.Context {
+		return log.WithLevel(log.WithNames(context.Background(), names...), level)
+	}
+
+	newLogger := func(opts ...DriverLoggerOption) (*driverLogger, *recordLogger) {
+		d := New("", WithDriverLogger(opts...))
+		l := *d.driverLogger
+		r := &recordLogger{}
+		l.logger = r
+
+		return &l, r
+	}
+
+	t.Run("Levels", func(t *testing.T) {
+		l, r := newLogger()
+
+		l.Log(event(log.TRACE, "ydb", "table", "create", "session"), "done")
+		l.Log(event(log.DEBUG, "ydb", "table", "session", "delete"), "done")
+		l.Log(event(log.INFO, "ydb", "discovery", "list", "endpoints"), "done",
+			log.Duration("latency", time.Millisecond),
+			log.Int("size", 3),
+		)
+		l.Log(event(log.WARN, "ydb", "table", "session", "delete"), "failed", log.Error(errors.New("timeout")))
+		l.Log(event(log.ERROR, "ydb", "driver", "conn", "invoke"), "failed", log.String("address", "ydb:2135"))
+		l.Log(event(log.FATAL, "ydb", "driver"), "failed")
+
+		require.Equal(t, []string{
+			"info: ydb.discovery.list.endpoints: done latency=1ms size=3",
+			"warn: ydb.table.session.delete: failed error=timeout",
+			"error: ydb.driver.conn.invoke: failed address=ydb:2135",
+			"error: ydb.driver: failed",
+		}, r.messages)
+	})
+
+	t.Run("MinLevel", func(t *testing.T) {
+		l, r := newLogger(WithDriverLogLevel(log.TRACE))
+
+		l.Log(event(log.TRACE, "ydb", "table", "create", "session"), "done", log.String("session_id", "42"))
+
+		require.Equal(t, []string{
+			"info: ydb.table.create.session: done session_id=42",
+		}, r.messages)
+	})
+
+	t.Run("SlowThreshold", func(t *testing.T) {
+		l, r := newLogger(WithDriverSlowThreshold(time.Second))
+
+		l.Log(event(log.DEBUG, "ydb", "table", "session", "query", "execute"), "done",
+			log.Duration("latency", 500*time.Millisecond),
+		)
+		l.Log(event(log.DEBUG, "ydb", "table", "session", "query", "execute"), "done",
+			log.Duration("latency", 2*time.Second),
+		)
+		l.Log(event(log.ERROR, "ydb", "table", "session", "query", "execute"), "failed",
+			log.Duration("latency", 3*time.Second),
+		)
+
+		require.Equal(t, []string{
+			"warn: SLOW YDB EVENT >= 1s ydb.table.session.query.execute: done latency=2s",
+			"error: SLOW YDB EVENT >= 1s ydb.table.session.query.execute: failed latency=3s",
+		}, r.messages)
+	})
+}
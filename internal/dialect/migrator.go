@@ -25,6 +25,10 @@ import (
 type Migrator struct {
 	migrator.Migrator
 
+	// stmt is statement of db of Migrator. Statement of migrator.Migrator DB is cloned with OpMigrate
+	// context, so errors of methods without error results (HasTable, HasIndex) are added to stmt.
+	stmt *gorm.Statement
+
 	cacheStore *sync.Map
 }
 
@@ -166,6 +170,8 @@ func (m Migrator) CreateTable(values ...interface{}) error { //nolint:funlen
 
 // DropTable drop table for values.
 func (m Migrator) DropTable(models ...interface{}) error {
+	defer m.forgetIndexes(models...)
+
 	for _, model := range models {
 		if m.HasTable(model) {
 			tx := m.DB.Session(&gorm.Session{})
@@ -200,28 +206,39 @@ func (m Migrator) HasTable(model interface{}) bool {
 		tableName = stmt.Table
 	} else {
 		s, err := m.schemaByValue(model)
-		checkAndAddError(stmt, xerrors.WithStacktrace(err))
 		if err != nil {
+			m.addError(xerrors.WithStacktrace(err))
+
 			return false
 		}
 		tableName = s.Table
 	}
 
 	sqlDB, err := m.DB.DB()
-	checkAndAddError(stmt, xerrors.WithStacktrace(fmt.Errorf("error getting database/sql driver from gorm: %w", err)))
 	if err != nil {
+		m.addError(xerrors.WithStacktrace(fmt.Errorf("error getting database/sql driver from gorm: %w", err)))
+
 		return false
 	}
 
 	db, err := ydbDriver.Unwrap(sqlDB)
-	checkAndAddError(stmt, xerrors.WithStacktrace(fmt.Errorf("ydb driver unwrap failed: %w", err)))
 	if err != nil {
+		m.addError(xerrors.WithStacktrace(fmt.Errorf("ydb driver unwrap failed: %w", err)))
+
 		return false
 	}
 
-	exists, err := sugar.IsTableExists(stmt.Context, db.Scheme(), m.fullTableName(tableName))
-	checkAndAddError(stmt, xerrors.WithStacktrace(err))
+	tablePath, err := m.fullTableName(tableName)
 	if err != nil {
+		m.addError(xerrors.WithStacktrace(err))
+
+		return false
+	}
+
+	exists, err := sugar.IsTableExists(stmt.Context, db.Scheme(), tablePath)
+	if err != nil {
+		m.addError(xerrors.WithStacktrace(err))
+
 		return false
 	}
 
@@ -238,7 +255,7 @@ func (m Migrator) AddColumn(value interface{}, name string) error {
 		}
 
 		if !f.IgnoreMigration {
-			err := m.DB.WithContext(ydbDriver.WithQueryMode(context.Background(), ydbDriver.SchemeQueryMode)).Exec(
+			err := m.DB.WithContext(m.schemeContext()).Exec(
 				"ALTER TABLE ? ADD ? ?",
 				m.CurrentTable(stmt), clause.Column{Name: f.DBName}, m.DB.Migrator().FullDataTypeOf(f),
 			).Error
@@ -257,7 +274,7 @@ func (m Migrator) DropColumn(value interface{}, name string) error {
 			name = field.DBName
 		}
 
-		err := m.DB.WithContext(ydbDriver.WithQueryMode(context.Background(), ydbDriver.SchemeQueryMode)).Exec(
+		err := m.DB.WithContext(m.schemeContext()).Exec(
 			"ALTER TABLE ? DROP COLUMN ?", m.CurrentTable(stmt), clause.Column{Name: name},
 		).Error
 
@@ -296,16 +313,9 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 			return xerrors.WithStacktrace(err)
 		}
 
-		pt := m.fullTableName(tableName)
-
-		var desc options.Description
-		err = cc.Table().Do(stmt.Context, func(ctx context.Context, s table.Session) (err error) {
-			desc, err = s.DescribeTable(ctx, pt)
-
-			return xerrors.WithStacktrace(err)
-		}, table.WithIdempotent())
+		desc, err := m.describeTable(stmt.Context, cc, tableName)
 		if err != nil {
-			return xerrors.WithStacktrace(fmt.Errorf("describe '%s' failed: %w", pt, err))
+			return xerrors.WithStacktrace(err)
 		}
 
 		var ct gorm.ColumnType
@@ -330,6 +340,131 @@ func (m Migrator) ColumnTypes(value interface{}) ([]gorm.ColumnType, error) {
 	return columnTypes, xerrors.WithStacktrace(execErr)
 }
 
+// HasIndex check has index `name` or not. Name may be name of index or name of index from model schema.
+func (m Migrator) HasIndex(value interface{}, name string) bool {
+	exists, err := m.hasIndex(value, name)
+	if err != nil {
+		m.addError(xerrors.WithStacktrace(err))
+	}
+
+	return exists
+}
+
+func (m Migrator) hasIndex(value interface{}, name string) (exists bool, _ error) {
+	err := m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				name = idx.Name
+			}
+		}
+
+		db, err := m.DB.DB()
+		if err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+
+		cc, err := ydbDriver.Unwrap(db)
+		if err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+
+		ctx := m.DB.Statement.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+
+		desc, err := m.describeTable(ctx, cc, stmt.Table)
+		if err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+
+		for _, idx := range desc.Indexes {
+			if idx.Name == name {
+				exists = true
+
+				break
+			}
+		}
+
+		return nil
+	})
+
+	return exists, xerrors.WithStacktrace(err)
+}
+
+// CreateIndex create index `name` for value.
+func (m Migrator) CreateIndex(value interface{}, name string) error {
+	defer m.forgetIndexes(value)
+
+	return xerrors.WithStacktrace(m.Migrator.CreateIndex(value, name))
+}
+
+// DropIndex drop index `name` of value.
+func (m Migrator) DropIndex(value interface{}, name string) error {
+	defer m.forgetIndexes(value)
+
+	return xerrors.WithStacktrace(m.Migrator.DropIndex(value, name))
+}
+
+// RenameIndex rename index `oldName` of value to `newName`.
+func (m Migrator) RenameIndex(value interface{}, oldName, newName string) error {
+	defer m.forgetIndexes(value)
+
+	return xerrors.WithStacktrace(m.Migrator.RenameIndex(value, oldName, newName))
+}
+
+// forgetIndexes removes indexes of tables of values from indexes checked by index hints (see UseIndex).
+func (m Migrator) forgetIndexes(values ...interface{}) {
+	d, ok := m.Dialector.(Dialector)
+	if !ok || d.indexes == nil {
+		return
+	}
+
+	for _, value := range values {
+		_ = m.RunWithValue(value, func(stmt *gorm.Statement) error {
+			d.indexes.Range(func(key, _ any) bool {
+				if k, ok := key.(string); ok && strings.HasPrefix(k, stmt.Table+"/") {
+					d.indexes.Delete(key)
+				}
+
+				return true
+			})
+
+			return nil
+		})
+	}
+}
+
+func (m Migrator) describeTable(
+	ctx context.Context, cc *ydbDriver.Driver, tableName string, opts ...options.DescribeTableOption,
+) (desc options.Description, _ error) {
+	pt, err := m.fullTableName(tableName)
+	if err != nil {
+		return desc, xerrors.WithStacktrace(err)
+	}
+
+	err = cc.Table().Do(ctx, func(ctx context.Context, s table.Session) (err error) {
+		desc, err = s.DescribeTable(ctx, pt, opts...)
+
+		return xerrors.WithStacktrace(err)
+	}, table.WithIdempotent())
+	if err != nil {
+		return desc, xerrors.WithStacktrace(fmt.Errorf("describe '%s' failed: %w", pt, err))
+	}
+
+	return desc, nil
+}
+
+// schemeContext returns context of migrator statement with scheme query mode.
+func (m Migrator) schemeContext() context.Context {
+	ctx := m.DB.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	return ydbDriver.WithQueryMode(ctx, ydbDriver.SchemeQueryMode)
+}
+
 func (m Migrator) schemaByValue(model interface{}) (*schema.Schema, error) {
 	s, err := schema.Parse(model, m.cacheStore, m.DB.NamingStrategy)
 	if err != nil {
@@ -339,29 +474,34 @@ func (m Migrator) schemaByValue(model interface{}) (*schema.Schema, error) {
 	return s, nil
 }
 
-func (m Migrator) fullTableName(tableName string) string {
+// fullTableName returns absolute path of table with name tableName.
+func (m Migrator) fullTableName(tableName string) (string, error) {
 	d, ok := m.Dialector.(Dialector)
 	if !ok {
-		checkAndAddError(m.DB.Statement, xerrors.WithStacktrace(errors.New("error conversion to Dialector")))
-
-		return ""
+		return "", xerrors.WithStacktrace(errors.New("error conversion to Dialector"))
 	}
 
 	localPath := path.Join(d.tablePathPrefix, tableName)
 
 	db, err := m.DB.DB()
 	if err != nil {
-		checkAndAddError(m.DB.Statement, xerrors.WithStacktrace(errors.New("error getting DB")))
-
-		return ""
+		return "", xerrors.WithStacktrace(fmt.Errorf("error getting DB: %w", err))
 	}
 
 	cc, err := ydbDriver.Unwrap(db)
 	if err != nil {
-		checkAndAddError(m.DB.Statement, xerrors.WithStacktrace(errors.New("error unwrapping db")))
+		return "", xerrors.WithStacktrace(fmt.Errorf("error unwrapping db: %w", err))
+	}
+
+	return path.Join(cc.Name(), localPath), nil
+}
 
-		return ""
+// addError adds err to statement of db of Migrator.
+func (m Migrator) addError(err error) {
+	stmt := m.stmt
+	if stmt == nil {
+		stmt = m.DB.Statement
 	}
 
-	return path.Join(cc.Name(), localPath)
+	checkAndAddError(stmt, err)
 }
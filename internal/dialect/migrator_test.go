@@ -5,7 +5,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
 	"gorm.io/gorm/migrator"
 	"gorm.io/gorm/schema"
 )
@@ -112,3 +114,42 @@ func TestMigrator_FullDataTypeOf(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func TestMigrator_Errors(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	db, err := gorm.Open(&Dialector{Conn: &fakePool{}}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	t.Run("fullTableName", func(t *testing.T) {
+		m, ok := db.Migrator().(Migrator)
+		require.True(t, ok)
+
+		_, err := m.fullTableName("products")
+		require.ErrorContains(t, err, "error getting DB")
+	})
+
+	t.Run("HasTable", func(t *testing.T) {
+		tx := db.Table("products")
+
+		require.False(t, tx.Migrator().HasTable(&Product{}))
+		require.ErrorContains(t, tx.Error, "error getting database/sql driver from gorm")
+	})
+
+	t.Run("HasIndex", func(t *testing.T) {
+		tx := db.Table("products")
+
+		require.False(t, tx.Migrator().HasIndex(&Product{}, "idx_code"))
+		require.Error(t, tx.Error)
+	})
+
+	t.Run("BulkCreate", func(t *testing.T) {
+		require.ErrorContains(t, BulkCreate(db, &Product{ID: 1}), "error getting DB")
+	})
+}
@@ -0,0 +1,122 @@
+package dialect
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// namedExpr is YQL named expression `$name = (expression);`.
+type namedExpr struct {
+	name  string
+	value interface{}
+}
+
+func (e namedExpr) Build(builder clause.Builder) {
+	_ = builder.WriteByte('$')
+	_, _ = builder.WriteString(e.name)
+	_, _ = builder.WriteString(" = ")
+
+	if _, ok := e.value.(*gorm.DB); ok {
+		_ = builder.WriteByte('(')
+		builder.AddVar(builder, e.value)
+		_ = builder.WriteByte(')')
+	} else {
+		builder.AddVar(builder, e.value)
+	}
+
+	_ = builder.WriteByte(';')
+}
+
+// isNamedExprName reports whether s is name of named expression: `$` followed by identifier
+// ([A-Za-z_][A-Za-z0-9_]*).
+func isNamedExprName(s string) bool {
+	if len(s) < 2 || s[0] != '$' {
+		return false
+	}
+
+	for i := 1; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 1:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// validNamedExprName returns error if name is not identifier or it is name of query parameter
+// ($p0, $p1, ... are names of numeric args of ydb-go-sdk and of typed parameters).
+func validNamedExprName(name string) error {
+	if !isNamedExprName("$" + name) {
+		return xerrors.WithStacktrace(fmt.Errorf("invalid name of named expression: %q", name))
+	}
+
+	if len(name) > 1 && name[0] == 'p' && strings.Trim(name[1:], "0123456789") == "" {
+		return xerrors.WithStacktrace(fmt.Errorf("name of named expression %q is reserved for query parameters", name))
+	}
+
+	return nil
+}
+
+// buildNamed builds clause of named expressions. Named expressions with invalid names are not built
+// and their errors are added to statement.
+func buildNamed(c clause.Clause, builder clause.Builder) {
+	if c.Expression == nil {
+		return
+	}
+
+	if exprs, ok := c.Expression.(namedExprs); ok {
+		for _, expr := range exprs {
+			if err := validNamedExprName(expr.name); err != nil {
+				if stmt, ok := builder.(*gorm.Statement); ok {
+					checkAndAddError(stmt, err)
+				}
+
+				return
+			}
+		}
+	}
+
+	c.Expression.Build(builder)
+}
+
+// namedExprs is clause of named expressions before generated statement.
+type namedExprs []namedExpr
+
+// Named returns clause which defines named expression `$name = (subquery);` before generated statement.
+// Value may be subquery (*gorm.DB), clause.Expr or value of query parameter (for example, ydb List value).
+// Named expression is referenced as `$name` in tables (db.Table("$name")), joins and conditions. Name must be
+// identifier other than names of query parameters ($p0, $p1, ...).
+func Named(name string, value interface{}) clause.Expression {
+	return namedExprs{{name: name, value: value}}
+}
+
+func (e namedExprs) Name() string {
+	return "NAMED"
+}
+
+func (e namedExprs) Build(builder clause.Builder) {
+	for i, expr := range e {
+		if i > 0 {
+			_ = builder.WriteByte(' ')
+		}
+		expr.Build(builder)
+	}
+}
+
+func (e namedExprs) MergeClause(c *clause.Clause) {
+	if exists, ok := c.Expression.(namedExprs); ok {
+		e = append(append(namedExprs{}, exists...), e...)
+	}
+
+	c.Expression = e
+}
@@ -0,0 +1,91 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestNamed(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint32
+	}
+
+	db := dryRunDB(t)
+
+	cheap := db.Model(&Product{}).Select("id", "code").Where("price < ?", 100)
+
+	for _, tt := range []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+		vars []interface{}
+	}{
+		{
+			name: "from",
+			tx:   db.Clauses(Named("cheap", cheap)).Table("$cheap").Where("code = ?", "D42").Find(&[]Product{}),
+			sql:  "$cheap = (SELECT `id`,`code` FROM `products` WHERE price < $1); SELECT * FROM $cheap WHERE code = $2",
+			vars: []interface{}{100, "D42"},
+		},
+		{
+			name: "join",
+			tx: db.Clauses(Named("cheap", cheap)).
+				Model(&Product{}).
+				Joins("JOIN $cheap AS c ON c.id = products.id").
+				Where("products.price > ?", 10).
+				Find(&[]Product{}),
+			sql: "$cheap = (SELECT `id`,`code` FROM `products` WHERE price < $1); " +
+				"SELECT `products`.`id`,`products`.`code`,`products`.`price` FROM `products` " +
+				"JOIN $cheap AS c ON c.id = products.id WHERE products.price > $2",
+			vars: []interface{}{100, 10},
+		},
+		{
+			name: "many",
+			tx: db.Clauses(
+				Named("cheap", cheap),
+				Named("codes", db.Table("$cheap").Select("code")),
+			).Table("$codes").Find(&[]string{}),
+			sql: "$cheap = (SELECT `id`,`code` FROM `products` WHERE price < $1); " +
+				"$codes = (SELECT code FROM $cheap); SELECT * FROM $codes",
+			vars: []interface{}{100},
+		},
+		{
+			name: "parameter",
+			tx: db.Clauses(Named("ids", types.ListValue(types.Uint32Value(1), types.Uint32Value(2)))).
+				Where("id IN $ids").
+				Find(&[]Product{}),
+			sql:  "$ids = $1; SELECT * FROM `products` WHERE id IN $ids",
+			vars: []interface{}{types.ListValue(types.Uint32Value(1), types.Uint32Value(2))},
+		},
+		{
+			name: "table",
+			tx: db.Clauses(Named("cheap", cheap), clause.From{Tables: []clause.Table{{Name: "$cheap"}}}).
+				Find(&[]Product{}),
+			sql:  "$cheap = (SELECT `id`,`code` FROM `products` WHERE price < $1); SELECT * FROM $cheap",
+			vars: []interface{}{100},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+			require.Equal(t, tt.vars, tt.tx.Statement.Vars)
+		})
+	}
+
+	t.Run("invalid name", func(t *testing.T) {
+		for _, name := range []string{"x = 1; DROP TABLE products; $y", "", "1x", "p0", "p12"} {
+			tx := db.Clauses(Named(name, cheap)).Table("$cheap").Find(&[]Product{})
+			require.Error(t, tx.Error, name)
+			require.NotContains(t, tx.Statement.SQL.String(), "DROP", name)
+		}
+
+		for _, name := range []string{"p", "p1x", "prices"} {
+			require.NoError(t, db.Clauses(Named(name, cheap)).Table("$"+name).Find(&[]Product{}).Error, name)
+		}
+	})
+}
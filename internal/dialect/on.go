@@ -0,0 +1,168 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// UpdateOn updates rows of model table with rows of source by primary key: `UPDATE table ON SELECT ...`.
+// Source may be subquery (*gorm.DB), ydb List of Structs or model values (struct, slice or array of structs).
+// Model of db is used for table name, model values source is used if db has no model. Pragmas and named
+// expressions of db (Pragma, WithPragma and Named) are rendered before statement. Conditions and other clauses
+// of db (Where, Joins, Limit and others) are not allowed: rows are filtered by source.
+func UpdateOn(db *gorm.DB, source interface{}) *gorm.DB {
+	return execOn(db, "?UPDATE ? ON ", source, bulkFields)
+}
+
+// DeleteOn deletes rows of model table by primary keys of source rows: `DELETE FROM table ON SELECT ...`.
+// Source may be subquery (*gorm.DB), ydb List of Structs or model values (struct, slice or array of structs).
+// Model of db is used for table name, model values source is used if db has no model. Pragmas and named
+// expressions of db (Pragma, WithPragma and Named) are rendered before statement. Conditions and other clauses
+// of db (Where, Joins, Limit and others) are not allowed: rows are filtered by source.
+func DeleteOn(db *gorm.DB, source interface{}) *gorm.DB {
+	return execOn(db, "?DELETE FROM ? ON ", source, func(s *schema.Schema) []*schema.Field {
+		return s.PrimaryFields
+	})
+}
+
+func execOn(db *gorm.DB, sql string, source interface{}, fields func(s *schema.Schema) []*schema.Field) *gorm.DB {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// session with context has own copy of statement, so parsing of schema does not change db statement
+	tx := db.WithContext(ctx)
+
+	if clauses := statementClauses(tx.Statement); len(clauses) > 0 {
+		_ = tx.AddError(xerrors.WithStacktrace(fmt.Errorf(
+			"clauses of statement are not allowed with ON of UPDATE and DELETE: %s", strings.Join(clauses, ", "),
+		)))
+
+		return tx
+	}
+
+	model := tx.Statement.Model
+	if model == nil {
+		switch source.(type) {
+		case *gorm.DB, types.Value:
+		default:
+			model = source
+		}
+	}
+
+	if tx.Statement.Table == "" {
+		if model == nil {
+			_ = tx.AddError(xerrors.WithStacktrace(errors.New("model or table of statement is not defined")))
+
+			return tx
+		}
+
+		if err := tx.Statement.Parse(model); err != nil {
+			_ = tx.AddError(xerrors.WithStacktrace(fmt.Errorf("error parsing schema: %w", err)))
+
+			return tx
+		}
+	}
+
+	prefix, table := onPrefix(tx), clause.Table{Name: tx.Statement.Table}
+
+	switch s := source.(type) {
+	case *gorm.DB:
+		return tx.Exec(sql+"?", prefix, table, markSubqueryVars([]interface{}{s})[0])
+	case types.Value:
+		return tx.Exec(sql+"SELECT * FROM AS_TABLE(?)", prefix, table, s)
+	}
+
+	if tx.Statement.Schema == nil {
+		if err := tx.Statement.Parse(source); err != nil {
+			_ = tx.AddError(xerrors.WithStacktrace(fmt.Errorf("error parsing schema: %w", err)))
+
+			return tx
+		}
+	}
+
+	rows, err := bulkRows(tx.Statement.Context, fields(tx.Statement.Schema), reflect.ValueOf(source))
+	if err != nil {
+		_ = tx.AddError(xerrors.WithStacktrace(err))
+
+		return tx
+	}
+
+	if len(rows) == 0 {
+		return tx
+	}
+
+	return tx.Exec(sql+"SELECT * FROM AS_TABLE(?)", prefix, table, types.ListValue(rows...))
+}
+
+// onClauses is names of clauses of statement which are rendered before UPDATE ON and DELETE ON.
+var onClauses = []string{"PRAGMA", "NAMED"}
+
+// clausesExpr is expression of clauses which are built by clause builders of statement and followed by space.
+type clausesExpr []clause.Clause
+
+func (e clausesExpr) Build(builder clause.Builder) {
+	stmt, ok := builder.(*gorm.Statement)
+	if !ok {
+		return
+	}
+
+	for _, c := range e {
+		if b, ok := stmt.DB.ClauseBuilders[c.Name]; ok {
+			b(c, stmt)
+		} else {
+			c.Build(stmt)
+		}
+
+		checkAndAddError(stmt, stmt.WriteByte(' '))
+	}
+}
+
+// onPrefix returns PRAGMA and NAMED clauses of statement of tx (with pragmas of Dialector) with marked subqueries.
+func onPrefix(tx *gorm.DB) clausesExpr {
+	if d, ok := dialectorOf(tx); ok && len(d.pragmas) > 0 {
+		tx.Statement.AddClauseIfNotExists(pragmas{})
+	}
+
+	prefix := make(clausesExpr, 0, len(onClauses))
+	for _, name := range onClauses {
+		if c, ok := tx.Statement.Clauses[name]; ok {
+			c.Name = name
+			c.Expression = markSubqueriesOf(c.Expression)
+			prefix = append(prefix, c)
+		}
+	}
+
+	return prefix
+}
+
+// statementClauses returns sorted names of clauses and joins of statement except onClauses.
+func statementClauses(stmt *gorm.Statement) []string {
+	clauses := make([]string, 0, len(stmt.Clauses)+1)
+	for name := range stmt.Clauses {
+		if !slices.Contains(onClauses, name) {
+			clauses = append(clauses, name)
+		}
+	}
+
+	sort.Strings(clauses)
+
+	if len(stmt.Joins) > 0 {
+		clauses = append(clauses, "JOIN")
+	}
+
+	return clauses
+}
@@ -0,0 +1,123 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+)
+
+func TestUpdateOnDeleteOn(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string `gorm:"not null"`
+		Price uint32 `gorm:"not null"`
+	}
+
+	db := dryRunDB(t)
+
+	list := types.ListValue(types.StructValue(types.StructFieldValue("id", types.Uint32Value(1))))
+
+	for _, tt := range []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+		vars []interface{}
+	}{
+		{
+			name: "update on subquery",
+			tx: UpdateOn(db.Model(&Product{}),
+				db.Model(&Product{}).Select("id", "price * 2 AS price").Where("code = ?", "D42"),
+			),
+			sql:  "UPDATE `products` ON SELECT `id`,price * 2 AS price FROM `products` WHERE code = $1",
+			vars: []interface{}{"D42"},
+		},
+		{
+			name: "update on values",
+			tx:   UpdateOn(db, []Product{{ID: 1, Code: "D42", Price: 100}, {ID: 2, Code: "D43", Price: 200}}),
+			sql:  "UPDATE `products` ON SELECT * FROM AS_TABLE($1)",
+			vars: []interface{}{types.ListValue(
+				types.StructValue(
+					types.StructFieldValue("id", types.Uint32Value(1)),
+					types.StructFieldValue("code", types.TextValue("D42")),
+					types.StructFieldValue("price", types.Uint32Value(100)),
+				),
+				types.StructValue(
+					types.StructFieldValue("id", types.Uint32Value(2)),
+					types.StructFieldValue("code", types.TextValue("D43")),
+					types.StructFieldValue("price", types.Uint32Value(200)),
+				),
+			)},
+		},
+		{
+			name: "delete on list",
+			tx:   DeleteOn(db.Table("products"), list),
+			sql:  "DELETE FROM `products` ON SELECT * FROM AS_TABLE($1)",
+			vars: []interface{}{list},
+		},
+		{
+			name: "delete on values",
+			tx:   DeleteOn(db.Model(&Product{}), &Product{ID: 1, Code: "D42"}),
+			sql:  "DELETE FROM `products` ON SELECT * FROM AS_TABLE($1)",
+			vars: []interface{}{list},
+		},
+		{
+			name: "update on named expression",
+			tx: UpdateOn(
+				db.Clauses(Named("cheap", db.Model(&Product{}).Select("id", "price").Where("price < ?", 100))).
+					Model(&Product{}),
+				db.Table("$cheap").Select("id", "price * 2 AS price"),
+			),
+			sql: "$cheap = (SELECT `id`,`price` FROM `products` WHERE price < $1); " +
+				"UPDATE `products` ON SELECT id,price * 2 AS price FROM $cheap",
+			vars: []interface{}{100},
+		},
+		{
+			name: "delete on values with pragma",
+			tx:   DeleteOn(db.Clauses(Pragma("TablePathPrefix", "/local")), []Product{{ID: 1}}),
+			sql:  "PRAGMA TablePathPrefix = \"/local\"; DELETE FROM `products` ON SELECT * FROM AS_TABLE($1)",
+			vars: []interface{}{list},
+		},
+		{
+			name: "delete on subquery",
+			tx:   DeleteOn(db.Model(&Product{}), db.Model(&Product{}).Select("id").Where("price = ?", 0)),
+			sql:  "DELETE FROM `products` ON SELECT `id` FROM `products` WHERE price = $1",
+			vars: []interface{}{0},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+			require.Equal(t, tt.vars, tt.tx.Statement.Vars)
+		})
+	}
+
+	t.Run("pragmas of dialector", func(t *testing.T) {
+		db := dryRunDB(t, WithPragma("AnsiInForEmptyOrNullableItemsCollections"))
+
+		tx := DeleteOn(db.Model(&Product{}), db.Model(&Product{}).Select("id").Where("price = ?", 0))
+		require.NoError(t, tx.Error)
+		require.Equal(t, "PRAGMA AnsiInForEmptyOrNullableItemsCollections; "+
+			"DELETE FROM `products` ON SELECT `id` FROM `products` WHERE price = $1", tx.Statement.SQL.String())
+	})
+
+	t.Run("without model", func(t *testing.T) {
+		require.Error(t, UpdateOn(db, list).Error)
+	})
+
+	t.Run("conditions", func(t *testing.T) {
+		tx := UpdateOn(db.Model(&Product{}).Where("price > ?", 10).Limit(1), list)
+		require.ErrorContains(t, tx.Error, "clauses of statement are not allowed with ON of UPDATE and DELETE: LIMIT, WHERE")
+		require.Empty(t, tx.Statement.SQL.String())
+
+		tx = DeleteOn(db.Model(&Product{}).Joins("JOIN orders ON orders.product_id = products.id"), list)
+		require.ErrorContains(t, tx.Error, "clauses of statement are not allowed with ON of UPDATE and DELETE: JOIN")
+	})
+
+	t.Run("empty values", func(t *testing.T) {
+		tx := DeleteOn(db, []Product{})
+		require.NoError(t, tx.Error)
+		require.Empty(t, tx.Statement.SQL.String())
+	})
+}
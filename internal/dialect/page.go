@@ -0,0 +1,167 @@
+package dialect
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// ErrInvalidPageToken is error of page token which is malformed or issued for other model.
+var ErrInvalidPageToken = errors.New("ydb: invalid page token")
+
+// Paginate reads page of rows of T ordered by primary key: `WHERE (k1, k2) > ($1, $2) ORDER BY k1, k2 LIMIT n`.
+// Page starts after row of token, first page is read with empty token. Next is token of next page or empty
+// string for last page. Token is opaque string with table, primary key columns and primary key of last row
+// of page, tokens of other tables or keys fail with ErrInvalidPageToken. Conditions of db are applied to
+// each page, db with own order or limit is not allowed. Model of query is T if db has no model.
+func Paginate[T any](db *gorm.DB, token string, limit int) ([]T, string, error) {
+	if limit <= 0 {
+		return nil, "", xerrors.WithStacktrace(fmt.Errorf("invalid page limit %d", limit))
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// session with context has own copy of statement, so parsing of schema does not change db statement
+	tx := db.WithContext(ctx)
+	if tx.Statement.Model == nil {
+		tx.Statement.Model = new(T)
+	}
+
+	if err := tx.Statement.Parse(tx.Statement.Model); err != nil {
+		return nil, "", xerrors.WithStacktrace(fmt.Errorf("error parsing schema: %w", err))
+	}
+
+	for _, name := range []string{"ORDER BY", "LIMIT"} {
+		if _, ok := tx.Statement.Clauses[name]; ok {
+			return nil, "", xerrors.WithStacktrace(fmt.Errorf("%s clause of db is not allowed for pagination", name))
+		}
+	}
+
+	fields := tx.Statement.Schema.PrimaryFields
+	if len(fields) == 0 {
+		return nil, "", xerrors.WithStacktrace(fmt.Errorf("model %s has no primary key", tx.Statement.Schema.Name))
+	}
+
+	columns := make([]interface{}, 0, len(fields))
+	orderBy := make([]clause.OrderByColumn, 0, len(fields))
+	for _, field := range fields {
+		column := clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+		columns = append(columns, column)
+		orderBy = append(orderBy, clause.OrderByColumn{Column: column})
+	}
+
+	if token != "" {
+		values, err := decodePageToken(tx.Statement, fields, token)
+		if err != nil {
+			return nil, "", xerrors.WithStacktrace(err)
+		}
+
+		tx = tx.Where(clause.Expr{SQL: "? > ?", Vars: []interface{}{columns, values}})
+	}
+
+	var rows []T
+	if err := tx.Clauses(clause.OrderBy{Columns: orderBy}).Limit(limit).Find(&rows).Error; err != nil {
+		return nil, "", xerrors.WithStacktrace(err)
+	}
+
+	if len(rows) < limit {
+		return rows, "", nil
+	}
+
+	next, err := encodePageToken(ctx, tx.Statement.Table, fields, reflect.ValueOf(&rows[len(rows)-1]).Elem())
+	if err != nil {
+		return nil, "", xerrors.WithStacktrace(err)
+	}
+
+	return rows, next, nil
+}
+
+// pageToken is content of page token: table, primary key columns and primary key values of last row of page.
+type pageToken struct {
+	Table   string            `json:"table"`
+	Columns []string          `json:"columns"`
+	Values  []json.RawMessage `json:"values"`
+}
+
+// encodePageToken encodes table, primary key columns and primary key of row rv as base64 of JSON.
+func encodePageToken(ctx context.Context, table string, fields []*schema.Field, rv reflect.Value) (string, error) {
+	token := pageToken{
+		Table:   table,
+		Columns: make([]string, 0, len(fields)),
+		Values:  make([]json.RawMessage, 0, len(fields)),
+	}
+
+	for _, field := range fields {
+		v, _ := field.ValueOf(ctx, reflect.Indirect(rv))
+
+		value, err := json.Marshal(v)
+		if err != nil {
+			return "", xerrors.WithStacktrace(fmt.Errorf("error encoding page token: %w", err))
+		}
+
+		token.Columns = append(token.Columns, field.DBName)
+		token.Values = append(token.Values, value)
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", xerrors.WithStacktrace(fmt.Errorf("error encoding page token: %w", err))
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// decodePageToken checks table and primary key columns of token, decodes primary key values from token into
+// types of fields and converts them to ydb values with types of columns.
+func decodePageToken(stmt *gorm.Statement, fields []*schema.Field, token string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("%w: %w", ErrInvalidPageToken, err))
+	}
+
+	var t pageToken
+	if err = json.Unmarshal(data, &t); err != nil {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("%w: %w", ErrInvalidPageToken, err))
+	}
+
+	if t.Table != stmt.Table {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("%w: token of table `%s` for table `%s`",
+			ErrInvalidPageToken, t.Table, stmt.Table,
+		))
+	}
+
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		columns = append(columns, field.DBName)
+	}
+
+	if !reflect.DeepEqual(t.Columns, columns) || len(t.Values) != len(fields) {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("%w: token of key %v for primary key %v",
+			ErrInvalidPageToken, t.Columns, columns,
+		))
+	}
+
+	values := make([]interface{}, 0, len(fields))
+	for i, field := range fields {
+		v := reflect.New(field.FieldType)
+		if err = json.Unmarshal(t.Values[i], v.Interface()); err != nil {
+			return nil, xerrors.WithStacktrace(fmt.Errorf("%w: column %s: %w", ErrInvalidPageToken, field.DBName, err))
+		}
+
+		values = append(values, typedValue(stmt, field.DBName, v.Elem().Interface()))
+	}
+
+	return values, nil
+}
@@ -0,0 +1,101 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPaginate(t *testing.T) {
+	type Event struct {
+		Source string    `gorm:"primarykey;not null"`
+		Time   time.Time `gorm:"primarykey;not null"`
+		Data   string
+	}
+
+	queryErr := errors.New("query error")
+	pool := &fakePool{err: queryErr}
+
+	db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	stmt := db.WithContext(context.Background()).Statement
+	require.NoError(t, stmt.Parse(&Event{}))
+	fields := stmt.Schema.PrimaryFields
+
+	last := Event{Source: "sensor", Time: time.Date(2024, 5, 1, 12, 0, 0, 42000, time.UTC)}
+	token, err := encodePageToken(context.Background(), "events", fields, reflect.ValueOf(&last))
+	require.NoError(t, err)
+
+	values, err := decodePageToken(stmt, fields, token)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		types.TextValue(last.Source),
+		types.TimestampValueFromTime(last.Time),
+	}, values)
+
+	t.Run("first page", func(t *testing.T) {
+		pool.queries = nil
+
+		_, _, err := Paginate[Event](db.Where("data <> ?", ""), "", 10)
+		require.ErrorIs(t, err, queryErr)
+		require.Equal(t, []string{
+			"SELECT * FROM `events` WHERE data <> $1 ORDER BY `events`.`source`,`events`.`time` LIMIT $2",
+		}, pool.queries)
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		pool.queries = nil
+
+		_, _, err := Paginate[Event](db, token, 10)
+		require.ErrorIs(t, err, queryErr)
+		require.Equal(t, []string{
+			"SELECT * FROM `events` WHERE (`events`.`source`,`events`.`time`) > ($1,$2) " +
+				"ORDER BY `events`.`source`,`events`.`time` LIMIT $3",
+		}, pool.queries)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		pool.queries = nil
+
+		otherTable, err := encodePageToken(context.Background(), "archived_events", fields, reflect.ValueOf(&last))
+		require.NoError(t, err)
+
+		otherKey, err := encodePageToken(context.Background(), "events", fields[:1], reflect.ValueOf(&last))
+		require.NoError(t, err)
+
+		for _, token := range []string{"!", "e30", "WyJzZW5zb3IiXQ", otherTable, otherKey} {
+			_, _, err := Paginate[Event](db, token, 10)
+			require.ErrorIs(t, err, ErrInvalidPageToken, token)
+		}
+
+		require.Empty(t, pool.queries)
+	})
+
+	t.Run("order and limit of db", func(t *testing.T) {
+		pool.queries = nil
+
+		_, _, err := Paginate[Event](db.Order("data"), "", 10)
+		require.ErrorContains(t, err, "ORDER BY clause of db is not allowed")
+
+		_, _, err = Paginate[Event](db.Limit(5), "", 10)
+		require.ErrorContains(t, err, "LIMIT clause of db is not allowed")
+
+		require.Empty(t, pool.queries)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		_, _, err := Paginate[Event](db, "", 0)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,341 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/sugar"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+var _ gorm.ConnPool = declaredPool{}
+
+// typedValue converts v to ydb Value with type of statement schema column (as it created by Migrator).
+// Value v returned as is for unknown columns, expressions and values which cannot be converted.
+func typedValue(stmt *gorm.Statement, column interface{}, v interface{}) interface{} {
+	switch v.(type) {
+	case types.Value, clause.Expression, *gorm.DB, sql.NamedArg, []interface{}:
+		return v
+	}
+
+	field := lookUpField(stmt, column)
+	if field == nil {
+		return v
+	}
+
+	t, err := fieldType(field)
+	if err != nil {
+		return v
+	}
+
+	value, err := toValue(t, v)
+	if err != nil {
+		return v
+	}
+
+	return value
+}
+
+// lookUpField returns statement schema field of column (clause.Column or string) or nil if column is unknown.
+// Columns of other tables are looked up in schemas of relationships (for example, joined tables).
+func lookUpField(stmt *gorm.Statement, column interface{}) *schema.Field {
+	if stmt.Schema == nil {
+		return nil
+	}
+
+	var (
+		s    = stmt.Schema
+		name string
+	)
+
+	switch c := column.(type) {
+	case clause.Column:
+		if c.Table != "" && c.Table != clause.CurrentTable && c.Table != stmt.Table {
+			s = relatedSchema(stmt.Schema, c.Table)
+			if s == nil {
+				return nil
+			}
+		}
+		name = c.Name
+	case string:
+		name = c
+	default:
+		return nil
+	}
+
+	if name == clause.PrimaryKey {
+		return s.PrioritizedPrimaryField
+	}
+
+	return s.LookUpField(name)
+}
+
+// relatedSchema returns schema of relationship with name (as it joined by Joins("Orders")) or with table,
+// or nil if s has no such relationship.
+func relatedSchema(s *schema.Schema, table string) *schema.Schema {
+	if rel, ok := s.Relationships.Relations[table]; ok {
+		return rel.FieldSchema
+	}
+
+	for _, rel := range s.Relationships.Relations {
+		if rel.FieldSchema.Table == table {
+			return rel.FieldSchema
+		}
+	}
+
+	return nil
+}
+
+// comparisonExpr matches raw comparison of column with single parameter (for example, `price > ?`).
+var comparisonExpr = regexp.MustCompile("^\\s*(`?[\\w/]+`?(?:\\.`?\\w+`?)?)\\s*(?:==?|!=|<>|<=|>=|<|>)\\s*\\?\\s*$")
+
+// comparedColumn returns column of raw comparison expression with single parameter.
+func comparedColumn(e clause.Expr) (clause.Column, bool) {
+	if len(e.Vars) != 1 {
+		return clause.Column{}, false
+	}
+
+	m := comparisonExpr.FindStringSubmatch(e.SQL)
+	if m == nil {
+		return clause.Column{}, false
+	}
+
+	name := strings.ReplaceAll(m[1], "`", "")
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return clause.Column{Table: name[:i], Name: name[i+1:]}, true
+	}
+
+	return clause.Column{Name: name}, true
+}
+
+// typedParams converts values of comparison expressions to ydb Values typed by schema columns.
+func typedParams(stmt *gorm.Statement, exprs []clause.Expression) []clause.Expression {
+	result := make([]clause.Expression, 0, len(exprs))
+
+	for _, expr := range exprs {
+		switch e := expr.(type) {
+		case clause.Eq:
+			if e.Value != nil {
+				e.Value = typedValue(stmt, e.Column, e.Value)
+			}
+			expr = e
+		case clause.Neq:
+			if e.Value != nil {
+				e.Value = typedValue(stmt, e.Column, e.Value)
+			}
+			expr = e
+		case clause.Gt:
+			e.Value = typedValue(stmt, e.Column, e.Value)
+			expr = e
+		case clause.Gte:
+			e.Value = typedValue(stmt, e.Column, e.Value)
+			expr = e
+		case clause.Lt:
+			e.Value = typedValue(stmt, e.Column, e.Value)
+			expr = e
+		case clause.Lte:
+			e.Value = typedValue(stmt, e.Column, e.Value)
+			expr = e
+		case clause.Expr:
+			if column, ok := comparedColumn(e); ok {
+				e.Vars = []interface{}{typedValue(stmt, column, e.Vars[0])}
+			}
+			expr = e
+		case clause.AndConditions:
+			expr = clause.AndConditions{Exprs: typedParams(stmt, e.Exprs)}
+		case clause.OrConditions:
+			expr = clause.OrConditions{Exprs: typedParams(stmt, e.Exprs)}
+		case clause.NotConditions:
+			expr = clause.NotConditions{Exprs: typedParams(stmt, e.Exprs)}
+		}
+
+		result = append(result, expr)
+	}
+
+	return result
+}
+
+// typedValues converts inserted values to ydb Values typed by schema columns.
+func typedValues(stmt *gorm.Statement, values clause.Values) clause.Values {
+	rows := make([][]interface{}, 0, len(values.Values))
+
+	for _, row := range values.Values {
+		typedRow := make([]interface{}, 0, len(row))
+		for i, v := range row {
+			if i < len(values.Columns) {
+				v = typedValue(stmt, values.Columns[i], v)
+			}
+			typedRow = append(typedRow, v)
+		}
+		rows = append(rows, typedRow)
+	}
+
+	return clause.Values{
+		Columns: values.Columns,
+		Values:  rows,
+	}
+}
+
+// typedAssignments converts assigned values to ydb Values typed by schema columns.
+func typedAssignments(stmt *gorm.Statement, set clause.Set) clause.Set {
+	result := make(clause.Set, 0, len(set))
+
+	for _, assignment := range set {
+		result = append(result, clause.Assignment{
+			Column: assignment.Column,
+			Value:  typedValue(stmt, assignment.Column, assignment.Value),
+		})
+	}
+
+	return result
+}
+
+// paramName returns name of i-th query parameter. Parameters are named as numeric args of ydb-go-sdk
+// ($p0, $p1, ...), so names of parameters are reserved by validNamedExprName.
+func paramName(i int) string {
+	return "$p" + strconv.Itoa(i)
+}
+
+// declareParams returns query with DECLARE of parameters and args as named args of parameters. Parameters are
+// declared with types of ydb Values (typed by schema columns) or with types of go values.
+func declareParams(query string, args []interface{}) (string, []interface{}, error) {
+	if len(args) == 0 {
+		return query, args, nil
+	}
+
+	var b strings.Builder
+
+	named := make([]interface{}, 0, len(args))
+	for i, arg := range args {
+		name := paramName(i)
+
+		param, err := sugar.ToYdbParam(sql.Named(name, arg))
+		if err != nil {
+			return "", nil, xerrors.WithStacktrace(fmt.Errorf("declare parameter %s error: %w", name, err))
+		}
+
+		b.WriteString("DECLARE ")
+		b.WriteString(name)
+		b.WriteString(" AS ")
+		b.WriteString(param.Value().Type().Yql())
+		b.WriteString(";\n")
+
+		named = append(named, sql.Named(name[1:], arg))
+	}
+
+	b.WriteString(query)
+
+	return b.String(), named, nil
+}
+
+// declaredPool is gorm.ConnPool which declares parameters of queries (see declareParams).
+type declaredPool struct {
+	gorm.ConnPool
+}
+
+func (p declaredPool) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	query, args, err := declareParams(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ConnPool.ExecContext(ctx, query, args...)
+}
+
+func (p declaredPool) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	query, args, err := declareParams(query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.ConnPool.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext executes query without DECLARE if parameters cannot be declared: sql.Row cannot hold error,
+// so error of parameters is reported by ydb-go-sdk.
+func (p declaredPool) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	if declared, named, err := declareParams(query, args); err == nil {
+		query, args = declared, named
+	}
+
+	return p.ConnPool.QueryRowContext(ctx, query, args...)
+}
+
+// isTx reports whether pool is connection pool of transaction.
+func isTx(pool gorm.ConnPool) bool {
+	if p, ok := pool.(declaredPool); ok {
+		pool = p.ConnPool
+	}
+
+	_, ok := pool.(gorm.TxCommitter)
+
+	return ok
+}
+
+const paramsKey = "ydb:params"
+
+// beforeParams replaces connection pool of statement with declaredPool. Statements of nested queries (for example,
+// associations and preloads) inherit declaredPool of parent statement.
+func beforeParams(db *gorm.DB) {
+	if _, ok := db.Statement.ConnPool.(declaredPool); ok {
+		return
+	}
+
+	db.InstanceSet(paramsKey, db.Statement.ConnPool)
+	db.Statement.ConnPool = declaredPool{ConnPool: db.Statement.ConnPool}
+}
+
+// afterParams restores connection pool replaced by beforeParams, so transactions of statements are committed
+// by gorm with own connection pool.
+func afterParams(db *gorm.DB) {
+	if v, ok := db.InstanceGet(paramsKey); ok {
+		pool, _ := v.(gorm.ConnPool)
+		db.Statement.ConnPool = pool
+	}
+}
+
+// registerParamsCallbacks registers callbacks of declared parameters. Connection pool is replaced after
+// callbacks of tx control (they check prepared statements of transactions) and restored before commit of
+// default transactions and after rescan of truncated result sets.
+func (d Dialector) registerParamsCallbacks(db *gorm.DB) error {
+	if !d.typedParams {
+		return nil
+	}
+
+	const (
+		before    = "ydb:before_params"
+		after     = "ydb:after_params"
+		txControl = "ydb:before_tx_control"
+		commit    = "gorm:commit_or_rollback_transaction"
+	)
+
+	for _, err := range []error{
+		db.Callback().Create().Before("gorm:create").After(txControl).Register(before, beforeParams),
+		db.Callback().Create().After("gorm:create").Before(commit).Register(after, afterParams),
+		db.Callback().Query().Before("gorm:query").After(txControl).Register(before, beforeParams),
+		db.Callback().Query().After("ydb:truncated").Before("gorm:preload").Register(after, afterParams),
+		db.Callback().Update().Before("gorm:update").After(txControl).Register(before, beforeParams),
+		db.Callback().Update().After("gorm:update").Before(commit).Register(after, afterParams),
+		db.Callback().Delete().Before("gorm:delete").After(txControl).Register(before, beforeParams),
+		db.Callback().Delete().After("gorm:delete").Before(commit).Register(after, afterParams),
+		db.Callback().Row().Before("gorm:row").After(txControl).Register(before, beforeParams),
+		db.Callback().Row().After("gorm:row").Register(after, afterParams),
+		db.Callback().Raw().Before("gorm:raw").After(txControl).Register(before, beforeParams),
+		db.Callback().Raw().After("gorm:raw").Register(after, afterParams),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package dialect
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/logger"
+)
+
+func TestWithTypedParams(t *testing.T) {
+	d := &Dialector{}
+
+	WithTypedParams()(d)
+
+	require.True(t, d.typedParams)
+}
+
+func TestTypedParams(t *testing.T) { //nolint:funlen
+	type Order struct {
+		ID        uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		ProductID uint32
+		Price     uint64
+	}
+
+	type Product struct {
+		ID     uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code   *string
+		Price  uint16
+		Orders []Order
+	}
+
+	db := dryRunDB(t, WithTypedParams())
+
+	tests := []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+		vars []interface{}
+	}{
+		{
+			name: "create",
+			tx:   db.Create(&Product{ID: 1, Price: 100}),
+			sql:  "UPSERT INTO `products` (`id`,`code`,`price`) VALUES ($p0,$p1,$p2)",
+			vars: []interface{}{
+				types.Uint32Value(1),
+				types.NullValue(types.TypeText),
+				types.OptionalValue(types.Uint16Value(100)),
+			},
+		},
+		{
+			name: "update",
+			tx:   db.Model(&Product{ID: 1}).Update("price", 200),
+			sql:  "UPDATE `products` SET `price`=$p0 WHERE `id` = $p1",
+			vars: []interface{}{
+				types.OptionalValue(types.Uint16Value(200)),
+				types.Uint32Value(1),
+			},
+		},
+		{
+			name: "where map",
+			tx:   db.Where(map[string]interface{}{"code": "D42", "price": nil}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE `code` = $p0 AND `price` IS NULL",
+			vars: []interface{}{
+				types.OptionalValue(types.TextValue("D42")),
+			},
+		},
+		{
+			name: "where struct",
+			tx:   db.Not(&Product{Price: 10}).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE `products`.`price` <> $p0",
+			vars: []interface{}{
+				types.OptionalValue(types.Uint16Value(10)),
+			},
+		},
+		{
+			name: "where joined table",
+			tx: db.Joins("JOIN orders ON orders.product_id = products.id").
+				Where(clause.Eq{Column: clause.Column{Table: "orders", Name: "price"}, Value: 10}).
+				Where(clause.Eq{Column: clause.Column{Table: "products", Name: "price"}, Value: 10}).
+				Find(&[]Product{}),
+			sql: "SELECT `products`.`id`,`products`.`code`,`products`.`price` FROM `products` " +
+				"JOIN orders ON orders.product_id = products.id WHERE `orders`.`price` = $p0 AND `products`.`price` = $p1",
+			vars: []interface{}{
+				types.OptionalValue(types.Uint64Value(10)),
+				types.OptionalValue(types.Uint16Value(10)),
+			},
+		},
+		{
+			name: "where joined relationship",
+			tx: db.Joins("JOIN orders AS Orders ON Orders.product_id = products.id").
+				Where(clause.Eq{Column: clause.Column{Table: "Orders", Name: "price"}, Value: 10}).
+				Where(clause.Eq{Column: clause.Column{Table: "customers", Name: "price"}, Value: 10}).
+				Find(&[]Product{}),
+			sql: "SELECT `products`.`id`,`products`.`code`,`products`.`price` FROM `products` " +
+				"JOIN orders AS Orders ON Orders.product_id = products.id " +
+				"WHERE `Orders`.`price` = $p0 AND `customers`.`price` = $p1",
+			vars: []interface{}{
+				types.OptionalValue(types.Uint64Value(10)),
+				10,
+			},
+		},
+		{
+			name: "raw expression",
+			tx:   db.Where("price > ?", 10).Where("`orders`.`price` <= ?", 20).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE price > $p0 AND `orders`.`price` <= $p1",
+			vars: []interface{}{
+				types.OptionalValue(types.Uint16Value(10)),
+				types.OptionalValue(types.Uint64Value(20)),
+			},
+		},
+		{
+			name: "raw expression with many parameters",
+			tx:   db.Where("price BETWEEN ? AND ?", 10, 20).Find(&[]Product{}),
+			sql:  "SELECT * FROM `products` WHERE price BETWEEN $p0 AND $p1",
+			vars: []interface{}{10, 20},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+			require.Equal(t, tt.vars, tt.tx.Statement.Vars)
+		})
+	}
+}
+
+func TestTypedParams_Declare(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  *string
+		Price uint16
+	}
+
+	pool := &fakePool{}
+
+	d := New("", WithTypedParams())
+	d.Conn = pool
+
+	db, err := gorm.Open(d, &gorm.Config{DisableAutomaticPing: true, Logger: logger.Discard})
+	require.NoError(t, err)
+
+	require.NoError(t, db.Create(&Product{ID: 1, Price: 100}).Error)
+	require.Equal(t, "DECLARE $p0 AS Uint32;\nDECLARE $p1 AS Optional<Utf8>;\nDECLARE $p2 AS Optional<Uint16>;\n"+
+		"UPSERT INTO `products` (`id`,`code`,`price`) VALUES ($p0,$p1,$p2)", pool.queries[0])
+	require.Equal(t, []interface{}{
+		sql.Named("p0", types.Uint32Value(1)),
+		sql.Named("p1", types.NullValue(types.TypeText)),
+		sql.Named("p2", types.OptionalValue(types.Uint16Value(100))),
+	}, pool.args[0])
+	require.Equal(t, 1, pool.commits, "default transaction not committed")
+
+	require.NoError(t, db.Exec("SELECT ?", "D42").Error)
+	require.Equal(t, "DECLARE $p0 AS Utf8;\nSELECT $p0", pool.queries[1])
+	require.Equal(t, []interface{}{sql.Named("p0", "D42")}, pool.args[1])
+
+	require.NoError(t, db.Exec("SELECT 1").Error)
+	require.Equal(t, "SELECT 1", pool.queries[2])
+
+	tx := db.Exec("SELECT ?", make(chan int))
+	require.Error(t, tx.Error)
+	require.IsType(t, &fakePool{}, tx.Statement.ConnPool.(connPool).ConnPool, "connection pool not restored")
+	require.Len(t, pool.queries, 3)
+}
+
+func TestIsTx(t *testing.T) {
+	pool := &fakePool{}
+
+	require.False(t, isTx(pool))
+	require.False(t, isTx(declaredPool{ConnPool: pool}))
+	require.True(t, isTx((*fakeTx)(pool)))
+	require.True(t, isTx(declaredPool{ConnPool: (*fakeTx)(pool)}))
+}
+
+func TestTypedParamsDisabled(t *testing.T) {
+	type Product struct {
+		ID    uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Price uint16
+	}
+
+	tx := dryRunDB(t).Create(&Product{ID: 1, Price: 100})
+	require.NoError(t, tx.Error)
+	require.Equal(t, []interface{}{uint32(1), uint16(100)}, tx.Statement.Vars)
+}
@@ -0,0 +1,256 @@
+package dialect
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"gorm.io/gorm"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// Plan is ydb query plan of statement.
+type Plan struct {
+	// AST is YQL AST of statement.
+	AST string
+	// JSON is plan as it returned by ydb.
+	JSON string
+	// Stages is tree of plan stages.
+	Stages []PlanStage
+	// Tables is accesses of tables and indexes by statement.
+	Tables []TableAccess
+}
+
+// PlanStage is node of query plan (for example, "ResultSet" or "TableFullScan").
+type PlanStage struct {
+	ID        int
+	Type      string
+	Operators []PlanOperator
+	Tables    []string
+	Stages    []PlanStage
+}
+
+// PlanOperator is operator of plan stage (for example, "TableRangeScan" or "Filter").
+type PlanOperator struct {
+	Name string
+	// Table is table of operator which reads or writes table.
+	Table string
+	// Properties is other properties of operator as they are returned by ydb.
+	Properties map[string]interface{}
+}
+
+// TableAccess is reads and writes of table or index by statement.
+type TableAccess struct {
+	// Table is path of table.
+	Table string
+	// Index is name of index for reads and writes of index table.
+	Index  string
+	Reads  []TableRead
+	Writes []TableWrite
+}
+
+// TableRead is read of table (for example, "FullScan", "Scan" or "Lookup").
+type TableRead struct {
+	Type     string
+	Columns  []string
+	ScanBy   []string
+	LookupBy []string
+}
+
+// FullScan reports whether read is full scan of table.
+func (r TableRead) FullScan() bool {
+	return r.Type == "FullScan"
+}
+
+// TableWrite is write of table (for example, "MultiUpsert" or "MultiErase").
+type TableWrite struct {
+	Type    string
+	Columns []string
+}
+
+// FullScans returns accesses of tables and indexes with full scan reads.
+func (p *Plan) FullScans() []TableAccess {
+	var accesses []TableAccess
+	for _, access := range p.Tables {
+		for _, read := range access.Reads {
+			if read.FullScan() {
+				accesses = append(accesses, access)
+
+				break
+			}
+		}
+	}
+
+	return accesses
+}
+
+// ExplainPlan executes query of db in explain mode and returns query plan. Statement is built as for
+// Rows, so conditions, joins and raw SQL of db are explained.
+func ExplainPlan(db *gorm.DB) (*Plan, error) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	rows, err := db.WithContext(ydbDriver.WithQueryMode(ctx, ydbDriver.ExplainQueryMode)).Rows()
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		return nil, xerrors.WithStacktrace(errors.New("explain returned no plan"))
+	}
+
+	values := make([]string, len(columns))
+	dst := make([]interface{}, 0, len(columns))
+	for i := range values {
+		dst = append(dst, &values[i])
+	}
+
+	if err = rows.Scan(dst...); err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	var ast, plan string
+	for i, column := range columns {
+		switch strings.ToLower(column) {
+		case "ast":
+			ast = values[i]
+		case "plan":
+			plan = values[i]
+		}
+	}
+
+	return parsePlan(ast, plan)
+}
+
+// planJSON is ydb query plan in JSON format.
+type planJSON struct {
+	Plan   planNodeJSON `json:"Plan"`
+	Tables []struct {
+		Name  string `json:"name"`
+		Reads []struct {
+			Type     string   `json:"type"`
+			Columns  []string `json:"columns"`
+			ScanBy   []string `json:"scan_by"`
+			LookupBy []string `json:"lookup_by"`
+		} `json:"reads"`
+		Writes []struct {
+			Type    string   `json:"type"`
+			Columns []string `json:"columns"`
+		} `json:"writes"`
+	} `json:"tables"`
+}
+
+type planNodeJSON struct {
+	ID        int                      `json:"PlanNodeId"`
+	Type      string                   `json:"Node Type"`
+	Operators []map[string]interface{} `json:"Operators"`
+	Tables    []string                 `json:"Tables"`
+	Plans     []planNodeJSON           `json:"Plans"`
+}
+
+// indexImplTable is name of implementation table of secondary index: `table/index/indexImplTable`.
+const indexImplTable = "indexImplTable"
+
+func parsePlan(ast, plan string) (*Plan, error) {
+	var p planJSON
+	if err := json.Unmarshal([]byte(plan), &p); err != nil {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("error parsing plan: %w", err))
+	}
+
+	tables := make([]TableAccess, 0, len(p.Tables))
+	for _, t := range p.Tables {
+		access := TableAccess{Table: t.Name}
+		if dir, name := splitIndexTable(t.Name); name != "" {
+			access.Table, access.Index = dir, name
+		}
+
+		for _, r := range t.Reads {
+			access.Reads = append(access.Reads, TableRead{
+				Type:     r.Type,
+				Columns:  r.Columns,
+				ScanBy:   r.ScanBy,
+				LookupBy: r.LookupBy,
+			})
+		}
+
+		for _, w := range t.Writes {
+			access.Writes = append(access.Writes, TableWrite{
+				Type:    w.Type,
+				Columns: w.Columns,
+			})
+		}
+
+		tables = append(tables, access)
+	}
+
+	return &Plan{
+		AST:    ast,
+		JSON:   plan,
+		Stages: planStages(p.Plan.Plans),
+		Tables: tables,
+	}, nil
+}
+
+// splitIndexTable splits path of index implementation table to path of table and name of index.
+func splitIndexTable(tablePath string) (table, index string) {
+	parts := strings.Split(tablePath, "/")
+	if len(parts) < 3 || parts[len(parts)-1] != indexImplTable {
+		return tablePath, ""
+	}
+
+	return strings.Join(parts[:len(parts)-2], "/"), parts[len(parts)-2]
+}
+
+func planStages(nodes []planNodeJSON) []PlanStage {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	stages := make([]PlanStage, 0, len(nodes))
+	for _, node := range nodes {
+		stage := PlanStage{
+			ID:     node.ID,
+			Type:   node.Type,
+			Tables: node.Tables,
+			Stages: planStages(node.Plans),
+		}
+
+		for _, properties := range node.Operators {
+			op := PlanOperator{Properties: make(map[string]interface{}, len(properties))}
+			for k, v := range properties {
+				switch s, _ := v.(string); k {
+				case "Name":
+					op.Name = s
+				case "Table":
+					op.Table = s
+				default:
+					op.Properties[k] = v
+				}
+			}
+
+			stage.Operators = append(stage.Operators, op)
+		}
+
+		stages = append(stages, stage)
+	}
+
+	return stages
+}
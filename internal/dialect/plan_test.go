@@ -0,0 +1,132 @@
+package dialect
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const testPlan = `{
+	"meta": {"version": "0.2", "type": "query"},
+	"tables": [
+		{
+			"name": "/local/products",
+			"reads": [
+				{"type": "FullScan", "columns": ["code", "id"], "scan_by": ["id (-∞, +∞)"]},
+				{"type": "Lookup", "columns": ["code", "id"], "lookup_by": ["id"]}
+			]
+		},
+		{
+			"name": "/local/products/idx_code/indexImplTable",
+			"reads": [{"type": "Scan", "columns": ["code", "id"], "scan_by": ["code [D42, D42]"]}]
+		},
+		{
+			"name": "/local/orders",
+			"writes": [{"type": "MultiUpsert", "columns": ["id", "product_id"]}]
+		}
+	],
+	"Plan": {
+		"Node Type": "Query",
+		"PlanNodeType": "Query",
+		"Plans": [
+			{
+				"Node Type": "ResultSet",
+				"PlanNodeId": 3,
+				"Plans": [
+					{
+						"Node Type": "Limit-TableFullScan",
+						"PlanNodeId": 1,
+						"Operators": [
+							{"Name": "Limit", "Limit": "10"},
+							{"Name": "TableFullScan", "Table": "products", "ReadColumns": ["code", "id"]}
+						],
+						"Tables": ["products"]
+					}
+				]
+			}
+		]
+	}
+}`
+
+func TestParsePlan(t *testing.T) {
+	plan, err := parsePlan("(let ...)", testPlan)
+	require.NoError(t, err)
+
+	require.Equal(t, "(let ...)", plan.AST)
+	require.Equal(t, testPlan, plan.JSON)
+
+	require.Equal(t, []PlanStage{
+		{
+			ID:   3,
+			Type: "ResultSet",
+			Stages: []PlanStage{
+				{
+					ID:   1,
+					Type: "Limit-TableFullScan",
+					Operators: []PlanOperator{
+						{Name: "Limit", Properties: map[string]interface{}{"Limit": "10"}},
+						{Name: "TableFullScan", Table: "products", Properties: map[string]interface{}{
+							"ReadColumns": []interface{}{"code", "id"},
+						}},
+					},
+					Tables: []string{"products"},
+				},
+			},
+		},
+	}, plan.Stages)
+
+	products := TableAccess{
+		Table: "/local/products",
+		Reads: []TableRead{
+			{Type: "FullScan", Columns: []string{"code", "id"}, ScanBy: []string{"id (-∞, +∞)"}},
+			{Type: "Lookup", Columns: []string{"code", "id"}, LookupBy: []string{"id"}},
+		},
+	}
+	require.Equal(t, []TableAccess{
+		products,
+		{
+			Table: "/local/products",
+			Index: "idx_code",
+			Reads: []TableRead{{Type: "Scan", Columns: []string{"code", "id"}, ScanBy: []string{"code [D42, D42]"}}},
+		},
+		{
+			Table:  "/local/orders",
+			Writes: []TableWrite{{Type: "MultiUpsert", Columns: []string{"id", "product_id"}}},
+		},
+	}, plan.Tables)
+
+	require.Equal(t, []TableAccess{products}, plan.FullScans())
+
+	_, err = parsePlan("", "not a plan")
+	require.Error(t, err)
+}
+
+func TestExplainPlan(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	queryErr := errors.New("query error")
+	pool := &fakePool{err: queryErr}
+
+	db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	_, err = ExplainPlan(db.Model(&Product{}).Where("code = ?", "D42").Limit(10))
+	require.ErrorIs(t, err, queryErr)
+
+	_, err = ExplainPlan(db.Raw("SELECT * FROM products WHERE id = ?", 42))
+	require.ErrorIs(t, err, queryErr)
+
+	require.Equal(t, []string{
+		"SELECT * FROM `products` WHERE code = $1 LIMIT $2",
+		"SELECT * FROM products WHERE id = $1",
+	}, pool.queries)
+}
@@ -0,0 +1,242 @@
+package dialect
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// pragma is YQL pragma statement.
+type pragma struct {
+	name   string
+	values []string
+}
+
+func (p pragma) Build(builder clause.Builder) {
+	_, _ = builder.WriteString("PRAGMA ")
+	_, _ = builder.WriteString(p.name)
+
+	switch len(p.values) {
+	case 0:
+	case 1:
+		_, _ = builder.WriteString(" = ")
+		_, _ = builder.WriteString(yqlString(p.values[0]))
+	default:
+		_ = builder.WriteByte('(')
+		for i, v := range p.values {
+			if i > 0 {
+				_, _ = builder.WriteString(", ")
+			}
+			_, _ = builder.WriteString(yqlString(v))
+		}
+		_ = builder.WriteByte(')')
+	}
+
+	_ = builder.WriteByte(';')
+}
+
+// pragmas is clause of PRAGMA statements before generated statement.
+type pragmas []pragma
+
+// Pragma returns clause which adds `PRAGMA name`, `PRAGMA name = "value"` or `PRAGMA name("value1", ...)`
+// before generated statement.
+func Pragma(name string, values ...string) clause.Expression {
+	return pragmas{{name: name, values: values}}
+}
+
+func (p pragmas) Name() string {
+	return "PRAGMA"
+}
+
+func (p pragmas) Build(builder clause.Builder) {
+	for i, pragma := range p {
+		if i > 0 {
+			_ = builder.WriteByte(' ')
+		}
+		pragma.Build(builder)
+	}
+}
+
+func (p pragmas) MergeClause(c *clause.Clause) {
+	if exists, ok := c.Expression.(pragmas); ok {
+		p = append(append(pragmas{}, exists...), p...)
+	}
+
+	c.Expression = p
+}
+
+// WithPragma apply pragma to statements generated by gorm (statements of Raw and Exec are not changed).
+// Pragma is rendered as `PRAGMA name`, `PRAGMA name = "value"` or `PRAGMA name("value1", ...)`.
+func WithPragma(name string, values ...string) Option {
+	return func(d *Dialector) {
+		d.pragmas = append(d.pragmas, pragma{name: name, values: values})
+	}
+}
+
+// pragmaCallback adds PRAGMA clause to statements if Dialector has pragmas. Subqueries of statement are
+// marked before building, so PRAGMA clause is not added to subqueries.
+func (d Dialector) pragmaCallback(db *gorm.DB) {
+	if db.Error != nil || len(d.pragmas) == 0 {
+		return
+	}
+
+	markSubqueries(db.Statement)
+
+	if isSubquery(db) {
+		return
+	}
+
+	db.Statement.AddClauseIfNotExists(pragmas{})
+}
+
+// subquerySetting is setting of subqueries marked by markSubqueries.
+const subquerySetting = "ydb:subquery"
+
+func isSubquery(db *gorm.DB) bool {
+	v, ok := db.Get(subquerySetting)
+
+	return ok && v == true
+}
+
+// markSubqueries marks subqueries (*gorm.DB vars) of clauses, table expression and joins of statement with
+// subquerySetting. Subqueries are replaced with marked sessions, so marks are not added to source subqueries.
+func markSubqueries(stmt *gorm.Statement) {
+	for name, c := range stmt.Clauses {
+		c.Expression = markSubqueriesOf(c.Expression)
+		stmt.Clauses[name] = c
+	}
+
+	if stmt.TableExpr != nil {
+		tableExpr := *stmt.TableExpr
+		tableExpr.Vars = markSubqueryVars(tableExpr.Vars)
+		stmt.TableExpr = &tableExpr
+	}
+
+	for i := range stmt.Joins {
+		stmt.Joins[i].Conds = markSubqueryVars(stmt.Joins[i].Conds)
+	}
+}
+
+func markSubqueriesOf(expr clause.Expression) clause.Expression {
+	switch e := expr.(type) {
+	case clause.Expr:
+		e.Vars = markSubqueryVars(e.Vars)
+
+		return e
+	case clause.NamedExpr:
+		e.Vars = markSubqueryVars(e.Vars)
+
+		return e
+	case clause.IN:
+		e.Values = markSubqueryVars(e.Values)
+
+		return e
+	case clause.Where:
+		e.Exprs = markSubqueryExprs(e.Exprs)
+
+		return e
+	case clause.AndConditions:
+		e.Exprs = markSubqueryExprs(e.Exprs)
+
+		return e
+	case clause.OrConditions:
+		e.Exprs = markSubqueryExprs(e.Exprs)
+
+		return e
+	case clause.NotConditions:
+		e.Exprs = markSubqueryExprs(e.Exprs)
+
+		return e
+	case clause.Select:
+		if e.Expression != nil {
+			e.Expression = markSubqueriesOf(e.Expression)
+		}
+
+		return e
+	case clause.From:
+		joins := make([]clause.Join, 0, len(e.Joins))
+		for _, join := range e.Joins {
+			if join.Expression != nil {
+				join.Expression = markSubqueriesOf(join.Expression)
+			}
+			join.ON.Exprs = markSubqueryExprs(join.ON.Exprs)
+			joins = append(joins, join)
+		}
+		e.Joins = joins
+
+		return e
+	case namedExprs:
+		marked := make(namedExprs, 0, len(e))
+		for _, named := range e {
+			named.value = markSubqueryVars([]interface{}{named.value})[0]
+			marked = append(marked, named)
+		}
+
+		return marked
+	default:
+		return expr
+	}
+}
+
+func markSubqueryExprs(exprs []clause.Expression) []clause.Expression {
+	if len(exprs) == 0 {
+		return exprs
+	}
+
+	marked := make([]clause.Expression, 0, len(exprs))
+	for _, expr := range exprs {
+		marked = append(marked, markSubqueriesOf(expr))
+	}
+
+	return marked
+}
+
+func markSubqueryVars(vars []interface{}) []interface{} {
+	var marked []interface{}
+
+	for i, v := range vars {
+		subquery, ok := v.(*gorm.DB)
+		if !ok || isSubquery(subquery) {
+			continue
+		}
+
+		if marked == nil {
+			marked = append([]interface{}{}, vars...)
+		}
+
+		marked[i] = subquery.Session(&gorm.Session{}).Set(subquerySetting, true)
+	}
+
+	if marked == nil {
+		return vars
+	}
+
+	return marked
+}
+
+// buildPragmas builds PRAGMA clause with Dialector pragmas followed by statement pragmas.
+func (d Dialector) buildPragmas(c clause.Clause, builder clause.Builder) {
+	p := append(pragmas{}, d.pragmas...)
+	if statementPragmas, ok := c.Expression.(pragmas); ok {
+		p = append(p, statementPragmas...)
+	}
+
+	p.Build(builder)
+}
+
+func (d Dialector) registerPragmaCallbacks(db *gorm.DB) error {
+	const name = "ydb:pragma"
+
+	for _, err := range []error{
+		db.Callback().Create().Before("gorm:create").Register(name, d.pragmaCallback),
+		db.Callback().Query().Before("gorm:query").Register(name, d.pragmaCallback),
+		db.Callback().Update().Before("gorm:update").Register(name, d.pragmaCallback),
+		db.Callback().Delete().Before("gorm:delete").Register(name, d.pragmaCallback),
+		db.Callback().Row().Before("gorm:row").Register(name, d.pragmaCallback),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
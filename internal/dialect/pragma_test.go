@@ -0,0 +1,109 @@
+package dialect
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestPragma(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	db := dryRunDB(t)
+	dbWithPragmas := dryRunDB(t,
+		WithPragma("AnsiInForEmptyOrNullableItemsCollections"),
+		WithPragma("TablePathPrefix", "/local/products"),
+	)
+
+	for _, tt := range []struct {
+		name string
+		tx   *gorm.DB
+		sql  string
+	}{
+		{
+			name: "without pragmas",
+			tx:   db.Find(&[]Product{}),
+			sql:  "SELECT * FROM `products`",
+		},
+		{
+			name: "statement pragma",
+			tx:   db.Clauses(Pragma("AnsiInForEmptyOrNullableItemsCollections")).Find(&[]Product{}),
+			sql:  "PRAGMA AnsiInForEmptyOrNullableItemsCollections; SELECT * FROM `products`",
+		},
+		{
+			name: "statement pragmas",
+			tx: db.Clauses(Pragma("TablePathPrefix", "/local"), Pragma("Warning", "disable", "1101")).
+				Find(&[]Product{}),
+			sql: `PRAGMA TablePathPrefix = "/local"; PRAGMA Warning("disable", "1101"); SELECT * FROM ` + "`products`",
+		},
+		{
+			name: "escaped values",
+			tx:   db.Clauses(Pragma("TablePathPrefix", "/local/café \\\"tab\t\a\"")).Find(&[]Product{}),
+			sql:  `PRAGMA TablePathPrefix = "/local/café \\\"tab\t\x07\""; SELECT * FROM ` + "`products`",
+		},
+		{
+			name: "dialector pragmas",
+			tx:   dbWithPragmas.Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"SELECT * FROM `products`",
+		},
+		{
+			name: "dialector and statement pragmas",
+			tx:   dbWithPragmas.Clauses(Pragma("Warning", "disable", "1101")).Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				`PRAGMA Warning("disable", "1101"); SELECT * FROM ` + "`products`",
+		},
+		{
+			name: "dialector pragmas with subquery",
+			tx:   dbWithPragmas.Where("id IN (?)", dbWithPragmas.Model(&Product{}).Select("id")).Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"SELECT * FROM `products` WHERE id IN (SELECT `id` FROM `products`)",
+		},
+		{
+			name: "dialector pragmas with named subquery",
+			tx: dbWithPragmas.Clauses(Named("ids", dbWithPragmas.Model(&Product{}).Select("id"))).
+				Where("id IN $ids").Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"$ids = (SELECT `id` FROM `products`); SELECT * FROM `products` WHERE id IN $ids",
+		},
+		{
+			name: "dialector pragmas with table subquery",
+			tx:   dbWithPragmas.Table("(?) AS p", dbWithPragmas.Model(&Product{}).Select("id")).Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"SELECT * FROM (SELECT `id` FROM `products`) AS p",
+		},
+		{
+			name: "dialector pragmas with discard logger",
+			tx:   dbWithPragmas.Session(&gorm.Session{Logger: logger.Discard}).Find(&[]Product{}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"SELECT * FROM `products`",
+		},
+		{
+			name: "create",
+			tx:   db.Clauses(Pragma("AnsiInForEmptyOrNullableItemsCollections")).Create(&Product{ID: 1}),
+			sql:  "PRAGMA AnsiInForEmptyOrNullableItemsCollections; UPSERT INTO `products` (`id`,`code`) VALUES ($1,$2)",
+		},
+		{
+			name: "update",
+			tx: db.Clauses(Pragma("AnsiInForEmptyOrNullableItemsCollections")).
+				Model(&Product{ID: 1}).Update("code", "D42"),
+			sql: "PRAGMA AnsiInForEmptyOrNullableItemsCollections; UPDATE `products` SET `code`=$1 WHERE `id` = $2",
+		},
+		{
+			name: "delete",
+			tx:   dbWithPragmas.Delete(&Product{ID: 1}),
+			sql: `PRAGMA AnsiInForEmptyOrNullableItemsCollections; PRAGMA TablePathPrefix = "/local/products"; ` +
+				"DELETE FROM `products` WHERE `products`.`id` = $1",
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, tt.tx.Error)
+			require.Equal(t, tt.sql, tt.tx.Statement.SQL.String())
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package dialect
+
+import (
+	"context"
+
+	ydb "github.com/ydb-platform/ydb-go-sdk/v3"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+)
+
+// Query modes of ydb queries reported by queryModeOptions.
+const (
+	queryModeData      = "data"
+	queryModeScan      = "scan"
+	queryModeExplain   = "explain"
+	queryModeScripting = "scripting"
+	queryModeQuery     = "query"
+)
+
+// queryModeOptions returns ydb options which call fc with context and query mode of each ydb query:
+// "data", "scan", "explain" and "scripting" for table service or "query" for query service. Query mode of
+// statement is not reported by database/sql driver, so it is detected by ydb-go-sdk events of queries.
+func queryModeOptions(fc func(ctx context.Context, mode string)) []ydb.Option {
+	report := func(ctx *context.Context, mode string) {
+		if ctx != nil && *ctx != nil {
+			fc(*ctx, mode)
+		}
+	}
+
+	return []ydb.Option{
+		ydb.WithTraceTable(ydbTrace.Table{
+			OnSessionQueryExecute: func(info ydbTrace.TableExecuteDataQueryStartInfo) func(
+				ydbTrace.TableExecuteDataQueryDoneInfo,
+			) {
+				report(info.Context, queryModeData)
+
+				return nil
+			},
+			OnTxExecute: func(info ydbTrace.TableTransactionExecuteStartInfo) func(
+				ydbTrace.TableTransactionExecuteDoneInfo,
+			) {
+				report(info.Context, queryModeData)
+
+				return nil
+			},
+			OnTxExecuteStatement: func(info ydbTrace.TableTransactionExecuteStatementStartInfo) func(
+				ydbTrace.TableTransactionExecuteStatementDoneInfo,
+			) {
+				report(info.Context, queryModeData)
+
+				return nil
+			},
+			OnSessionQueryStreamExecute: func(info ydbTrace.TableSessionQueryStreamExecuteStartInfo) func(
+				ydbTrace.TableSessionQueryStreamExecuteDoneInfo,
+			) {
+				report(info.Context, queryModeScan)
+
+				return nil
+			},
+			OnSessionQueryExplain: func(info ydbTrace.TableExplainQueryStartInfo) func(
+				ydbTrace.TableExplainQueryDoneInfo,
+			) {
+				report(info.Context, queryModeExplain)
+
+				return nil
+			},
+		}),
+		ydb.WithTraceScripting(ydbTrace.Scripting{
+			OnExecute: func(info ydbTrace.ScriptingExecuteStartInfo) func(ydbTrace.ScriptingExecuteDoneInfo) {
+				report(info.Context, queryModeScripting)
+
+				return nil
+			},
+			OnStreamExecute: func(info ydbTrace.ScriptingStreamExecuteStartInfo) func(
+				ydbTrace.ScriptingStreamExecuteIntermediateInfo,
+			) func(ydbTrace.ScriptingStreamExecuteDoneInfo) {
+				report(info.Context, queryModeScripting)
+
+				return nil
+			},
+		}),
+		ydb.WithTraceQuery(ydbTrace.Query{
+			OnSessionExec: func(info ydbTrace.QuerySessionExecStartInfo) func(ydbTrace.QuerySessionExecDoneInfo) {
+				report(info.Context, queryModeQuery)
+
+				return nil
+			},
+			OnSessionQuery: func(info ydbTrace.QuerySessionQueryStartInfo) func(ydbTrace.QuerySessionQueryDoneInfo) {
+				report(info.Context, queryModeQuery)
+
+				return nil
+			},
+			OnTxExec: func(info ydbTrace.QueryTxExecStartInfo) func(ydbTrace.QueryTxExecDoneInfo) {
+				report(info.Context, queryModeQuery)
+
+				return nil
+			},
+			OnTxQuery: func(info ydbTrace.QueryTxQueryStartInfo) func(ydbTrace.QueryTxQueryDoneInfo) {
+				report(info.Context, queryModeQuery)
+
+				return nil
+			},
+		}),
+	}
+}
@@ -0,0 +1,230 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result/named"
+	"golang.org/x/sync/errgroup"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// ReadTableOption is option for ReadTable helper.
+type ReadTableOption func(o *readTableOptions)
+
+type readTableOptions struct {
+	workers int
+}
+
+// WithReadTableWorkers limits count of partitions which are read concurrently. Default is GOMAXPROCS.
+func WithReadTableWorkers(workers int) ReadTableOption {
+	return func(o *readTableOptions) {
+		o.workers = workers
+	}
+}
+
+// ReadTable reads all rows of table with table service ReadTable and calls fc for each row decoded into T.
+// Table is split into key ranges of partitions (DescribeTable with shard key bounds), and partitions are
+// read concurrently, so fc is called from different goroutines and rows of different partitions are not
+// ordered. Read of partition is retried on retryable errors until first row of partition is passed to fc.
+// Rows are read from table of T or table of db (Table). Conditions of db are not applied.
+// ReadTable does not run gorm hooks and callbacks.
+func ReadTable[T any](db *gorm.DB, fc func(row *T) error, opts ...ReadTableOption) error {
+	o := readTableOptions{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	tx := db.WithContext(ctx)
+
+	if err := tx.Statement.Parse(new(T)); err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("error parsing schema: %w", err))
+	}
+
+	m, ok := tx.Migrator().(Migrator)
+	if !ok {
+		return xerrors.WithStacktrace(errors.New("error conversion to Migrator"))
+	}
+
+	sqlDB, err := tx.DB()
+	if err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("error getting database/sql driver from gorm: %w", err))
+	}
+
+	cc, err := ydbDriver.Unwrap(sqlDB)
+	if err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("ydb driver unwrap failed: %w", err))
+	}
+
+	desc, err := m.describeTable(ctx, cc, tx.Statement.Table, options.WithShardKeyBounds())
+	if err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	tablePath, err := m.fullTableName(tx.Statement.Table)
+	if err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+
+	columns, fields := readTableColumns(tx.Statement.Schema, desc.Columns)
+
+	return readPartitions(ctx, desc.KeyRanges, o.workers, func(ctx context.Context, kr options.KeyRange) error {
+		return readPartition(ctx, cc.Table(), tablePath, kr, columns, func(values []interface{}) error {
+			row := new(T)
+			if err := readTableRow(ctx, fields, reflect.ValueOf(row).Elem(), values); err != nil {
+				return xerrors.WithStacktrace(err)
+			}
+
+			return fc(row)
+		})
+	})
+}
+
+// readTableColumns returns names and fields of schema columns which exist in table.
+func readTableColumns(s *schema.Schema, tableColumns []options.Column) ([]string, []*schema.Field) {
+	exists := make(map[string]bool, len(tableColumns))
+	for _, c := range tableColumns {
+		exists[c.Name] = true
+	}
+
+	var (
+		columns = make([]string, 0, len(s.DBNames))
+		fields  = make([]*schema.Field, 0, len(s.DBNames))
+	)
+	for _, dbName := range s.DBNames {
+		if !exists[dbName] {
+			continue
+		}
+
+		columns = append(columns, dbName)
+		fields = append(fields, s.FieldsByDBName[dbName])
+	}
+
+	return columns, fields
+}
+
+// readPartitions calls read for each key range with at most workers concurrent calls. Table without
+// partition bounds is read as single range. First error cancels reading of other ranges.
+func readPartitions(
+	ctx context.Context, ranges []options.KeyRange, workers int,
+	read func(ctx context.Context, kr options.KeyRange) error,
+) error {
+	if len(ranges) == 0 {
+		ranges = []options.KeyRange{{}}
+	}
+
+	if workers <= 0 {
+		workers = 1
+	}
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(workers)
+
+	for _, kr := range ranges {
+		kr := kr
+
+		g.Go(func() error {
+			if err := ctx.Err(); err != nil {
+				return xerrors.WithStacktrace(err)
+			}
+
+			return read(ctx, kr)
+		})
+	}
+
+	return xerrors.WithStacktrace(g.Wait())
+}
+
+// readPartition reads columns of key range kr with StreamReadTable and calls fc with values of each row.
+// Errors after first delivered row are not retried, because rows of partition would be delivered twice.
+func readPartition(
+	ctx context.Context, c table.Client, tablePath string, kr options.KeyRange, columns []string,
+	fc func(values []interface{}) error,
+) error {
+	var (
+		delivered bool
+		readErr   error
+	)
+
+	err := c.Do(ctx, func(ctx context.Context, s table.Session) error {
+		res, err := s.StreamReadTable(ctx, tablePath, options.ReadKeyRange(kr), options.ReadColumns(columns...))
+		if err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+		defer func() {
+			_ = res.Close()
+		}()
+
+		for res.NextResultSet(ctx) {
+			for res.NextRow() {
+				values := make([]interface{}, len(columns))
+				dst := make([]named.Value, 0, len(columns))
+				for i := range columns {
+					dst = append(dst, named.Required(columns[i], &values[i]))
+				}
+
+				if err = res.ScanNamed(dst...); err != nil {
+					break
+				}
+
+				delivered = true
+
+				if err = fc(values); err != nil {
+					break
+				}
+			}
+
+			if err != nil {
+				break
+			}
+		}
+
+		if err == nil {
+			err = res.Err()
+		}
+
+		if err != nil && delivered {
+			readErr = err
+
+			return nil
+		}
+
+		return xerrors.WithStacktrace(err)
+	}, table.WithIdempotent())
+	if err != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("read table '%s' failed: %w", tablePath, err))
+	}
+
+	if readErr != nil {
+		return xerrors.WithStacktrace(fmt.Errorf("read table '%s' failed: %w", tablePath, readErr))
+	}
+
+	return nil
+}
+
+// readTableRow sets values of row columns to fields of struct rv.
+func readTableRow(ctx context.Context, fields []*schema.Field, rv reflect.Value, values []interface{}) error {
+	for i, field := range fields {
+		if err := field.Set(ctx, rv, values[i]); err != nil {
+			return xerrors.WithStacktrace(fmt.Errorf("field %s: %w", field.Name, err))
+		}
+	}
+
+	return nil
+}
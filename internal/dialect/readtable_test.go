@@ -0,0 +1,118 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/options"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
+	"gorm.io/gorm/schema"
+)
+
+func TestReadTableColumns(t *testing.T) {
+	type Product struct {
+		ID      uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code    string
+		Price   *uint
+		Ignored string `gorm:"-"`
+	}
+
+	s, err := schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	columns, fields := readTableColumns(s, []options.Column{{Name: "id"}, {Name: "price"}, {Name: "legacy"}})
+	require.Equal(t, []string{"id", "price"}, columns)
+	require.Equal(t, []*schema.Field{s.FieldsByDBName["id"], s.FieldsByDBName["price"]}, fields)
+}
+
+func TestReadTableRow(t *testing.T) {
+	type Product struct {
+		ID        uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code      string
+		Price     *uint
+		CreatedAt time.Time
+	}
+
+	s, err := schema.Parse(&Product{}, &sync.Map{}, schema.NamingStrategy{})
+	require.NoError(t, err)
+
+	createdAt := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	var p Product
+	err = readTableRow(context.Background(),
+		[]*schema.Field{s.FieldsByDBName["id"], s.FieldsByDBName["code"], s.FieldsByDBName["price"],
+			s.FieldsByDBName["created_at"]},
+		reflect.ValueOf(&p).Elem(),
+		[]interface{}{uint64(42), "D42", nil, createdAt},
+	)
+	require.NoError(t, err)
+	require.Equal(t, Product{ID: 42, Code: "D42", CreatedAt: createdAt}, p)
+}
+
+func TestReadPartitions(t *testing.T) {
+	ranges := []options.KeyRange{
+		{To: types.Uint64Value(10)},
+		{From: types.Uint64Value(10), To: types.Uint64Value(20)},
+		{From: types.Uint64Value(20), To: types.Uint64Value(30)},
+		{From: types.Uint64Value(30)},
+	}
+
+	t.Run("workers", func(t *testing.T) {
+		var (
+			mu      sync.Mutex
+			read    []options.KeyRange
+			active  int32
+			maxSeen int32
+		)
+
+		err := readPartitions(context.Background(), ranges, 2, func(_ context.Context, kr options.KeyRange) error {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+
+			mu.Lock()
+			read = append(read, kr)
+			if n > maxSeen {
+				maxSeen = n
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.ElementsMatch(t, ranges, read)
+		require.LessOrEqual(t, maxSeen, int32(2))
+	})
+
+	t.Run("single range", func(t *testing.T) {
+		var read []options.KeyRange
+
+		err := readPartitions(context.Background(), nil, 0, func(_ context.Context, kr options.KeyRange) error {
+			read = append(read, kr)
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []options.KeyRange{{}}, read)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		readErr := errors.New("read error")
+
+		var calls int32
+		err := readPartitions(context.Background(), ranges, 1, func(context.Context, options.KeyRange) error {
+			atomic.AddInt32(&calls, 1)
+
+			return readErr
+		})
+		require.ErrorIs(t, err, readErr)
+		require.Equal(t, int32(1), calls)
+	})
+}
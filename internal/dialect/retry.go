@@ -0,0 +1,142 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
+	"gorm.io/gorm"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// RetryOption is option for Retry and Transaction helpers.
+type RetryOption func(o *retryOptions)
+
+type retryOptions struct {
+	idempotent bool
+	attempts   int
+	txOptions  []*sql.TxOptions
+	onRetry    []func(attempt int, err error)
+	opts       []retry.Option
+}
+
+// WithRetryIdempotent marks operation as idempotent, so it will be retried on conditionally retryable
+// errors (transport errors, UNDETERMINED status and others).
+func WithRetryIdempotent(idempotent bool) RetryOption {
+	return func(o *retryOptions) {
+		o.idempotent = idempotent
+	}
+}
+
+// WithRetryAttempts limits attempts of operation. Attempts are not limited by default.
+func WithRetryAttempts(attempts int) RetryOption {
+	return func(o *retryOptions) {
+		o.attempts = attempts
+	}
+}
+
+// WithRetryTxOptions apply sql.TxOptions to transactions of Transaction helper.
+func WithRetryTxOptions(opts *sql.TxOptions) RetryOption {
+	return func(o *retryOptions) {
+		o.txOptions = append(o.txOptions, opts)
+	}
+}
+
+// WithRetryHook apply hook which called after each failed attempt of operation.
+func WithRetryHook(hook func(attempt int, err error)) RetryOption {
+	return func(o *retryOptions) {
+		o.onRetry = append(o.onRetry, hook)
+	}
+}
+
+// WithRetryOptions apply ydb-go-sdk retry options (backoff, budget, label, trace and others).
+func WithRetryOptions(opts ...retry.Option) RetryOption {
+	return func(o *retryOptions) {
+		o.opts = append(o.opts, opts...)
+	}
+}
+
+// Retry calls fc with session of db until it returns nil or non-retryable error.
+// Errors are classified with ydb-go-sdk retry semantics.
+func Retry(db *gorm.DB, fc func(tx *gorm.DB) error, opts ...RetryOption) error {
+	o := retryOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	retryOpts := append(make([]retry.Option, 0, len(o.opts)+2), o.opts...)
+	retryOpts = append(retryOpts, retry.WithIdempotent(o.idempotent))
+	if d, ok := dialectorOf(db); ok {
+		retryOpts = append(retryOpts, d.retryTraces()...)
+	}
+	if o.attempts > 0 {
+		retryOpts = append(retryOpts, retry.WithBudget(&attemptsBudget{left: int64(o.attempts - 1)}))
+	}
+
+	var attempt int
+
+	err := retry.Retry(ctx, func(ctx context.Context) error {
+		attempt++
+
+		err := fc(db.WithContext(ctx))
+		if err != nil {
+			for _, hook := range o.onRetry {
+				hook(attempt, err)
+			}
+		}
+
+		return err
+	}, retryOpts...)
+
+	return xerrors.WithStacktrace(err)
+}
+
+// retryTraces returns options which trace retries of Retry helper as retries of ydb-go-sdk
+// with traces of statement hooks.
+func (d Dialector) retryTraces() []retry.Option {
+	var opts []retry.Option
+	for _, hook := range d.hooks {
+		t := hook.RetryTrace()
+		opts = append(opts, retry.WithTrace(&t))
+	}
+
+	return opts
+}
+
+// Transaction calls fc in transaction with retries on retryable errors (for example, transaction locks
+// invalidation). Each attempt is executed in new transaction.
+func Transaction(db *gorm.DB, fc func(tx *gorm.DB) error, opts ...RetryOption) error {
+	o := retryOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+
+	return Retry(db, func(tx *gorm.DB) error {
+		return tx.Transaction(fc, o.txOptions...)
+	}, opts...)
+}
+
+// attemptsBudget is retry budget which allows limited count of retries.
+type attemptsBudget struct {
+	left int64
+}
+
+func (b *attemptsBudget) Acquire(context.Context) error {
+	if atomic.AddInt64(&b.left, -1) < 0 {
+		return budget.ErrNoQuota
+	}
+
+	return nil
+}
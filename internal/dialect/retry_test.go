@@ -0,0 +1,82 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry"
+	"github.com/ydb-platform/ydb-go-sdk/v3/retry/budget"
+	"gorm.io/gorm"
+)
+
+func TestRetry(t *testing.T) {
+	db := dryRunDB(t)
+
+	t.Run("success", func(t *testing.T) {
+		var calls int
+		err := Retry(db, func(tx *gorm.DB) error {
+			calls++
+			if calls < 3 {
+				return retry.RetryableError(errors.New("retryable error"))
+			}
+
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("non-retryable error", func(t *testing.T) {
+		var (
+			calls    int
+			attempts []int
+			errs     []error
+			someErr  = errors.New("some error")
+		)
+		err := Retry(db, func(tx *gorm.DB) error {
+			calls++
+
+			return someErr
+		}, WithRetryHook(func(attempt int, err error) {
+			attempts = append(attempts, attempt)
+			errs = append(errs, err)
+		}))
+		require.ErrorIs(t, err, someErr)
+		require.Equal(t, 1, calls)
+		require.Equal(t, []int{1}, attempts)
+		require.Equal(t, []error{someErr}, errs)
+	})
+
+	t.Run("attempts limit", func(t *testing.T) {
+		var calls int
+		err := Retry(db, func(tx *gorm.DB) error {
+			calls++
+
+			return retry.RetryableError(errors.New("retryable error"))
+		}, WithRetryAttempts(3), WithRetryIdempotent(true))
+		require.ErrorIs(t, err, budget.ErrNoQuota)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("context", func(t *testing.T) {
+		type ctxKey struct{}
+
+		ctx := context.WithValue(context.Background(), ctxKey{}, "value")
+		err := Retry(db.WithContext(ctx), func(tx *gorm.DB) error {
+			require.Equal(t, "value", tx.Statement.Context.Value(ctxKey{}))
+
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func Test_attemptsBudget(t *testing.T) {
+	b := &attemptsBudget{left: 2}
+
+	require.NoError(t, b.Acquire(context.Background()))
+	require.NoError(t, b.Acquire(context.Background()))
+	require.ErrorIs(t, b.Acquire(context.Background()), budget.ErrNoQuota)
+}
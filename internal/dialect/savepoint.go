@@ -0,0 +1,54 @@
+package dialect
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// ErrSavePointNotSupported is error of nested transaction with SavePointError policy.
+var ErrSavePointNotSupported = errors.New("ydb: savepoints are not supported, nested transactions are not allowed")
+
+// SavePointPolicy defines behavior of nested transactions (db.Transaction inside transaction), which are
+// implemented by gorm over savepoints. YDB has no savepoints.
+type SavePointPolicy int
+
+const (
+	// SavePointError fails nested transactions with ErrSavePointNotSupported. It is default policy.
+	SavePointError SavePointPolicy = iota
+	// SavePointFlatten executes nested transactions as part of outer transaction. Rollback of nested
+	// transaction rolls back whole outer transaction, so next queries and commit of outer transaction fail.
+	SavePointFlatten
+)
+
+// WithSavePointPolicy apply policy of nested transactions to Dialector.
+func WithSavePointPolicy(policy SavePointPolicy) Option {
+	return func(d *Dialector) {
+		d.savePointPolicy = policy
+	}
+}
+
+var _ gorm.SavePointerDialectorInterface = Dialector{}
+
+func (d Dialector) SavePoint(_ *gorm.DB, _ string) error {
+	if d.savePointPolicy == SavePointFlatten {
+		return nil
+	}
+
+	return xerrors.WithStacktrace(ErrSavePointNotSupported)
+}
+
+func (d Dialector) RollbackTo(tx *gorm.DB, _ string) error {
+	if d.savePointPolicy != SavePointFlatten {
+		return xerrors.WithStacktrace(ErrSavePointNotSupported)
+	}
+
+	committer, ok := tx.Statement.ConnPool.(gorm.TxCommitter)
+	if !ok {
+		return xerrors.WithStacktrace(gorm.ErrInvalidTransaction)
+	}
+
+	return xerrors.WithStacktrace(committer.Rollback())
+}
@@ -0,0 +1,80 @@
+package dialect
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestSavePointPolicy(t *testing.T) {
+	open := func(t *testing.T, pool *fakePool, opts ...Option) *gorm.DB {
+		t.Helper()
+
+		d := New("", opts...)
+		d.Conn = pool
+
+		db, err := gorm.Open(d, &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+
+		return db
+	}
+
+	nestedErr := errors.New("nested error")
+
+	t.Run("error", func(t *testing.T) {
+		pool := &fakePool{}
+
+		var nestedCalled bool
+		err := open(t, pool).Transaction(func(tx *gorm.DB) error {
+			return tx.Transaction(func(tx *gorm.DB) error {
+				nestedCalled = true
+
+				return nil
+			})
+		})
+		require.ErrorIs(t, err, ErrSavePointNotSupported)
+		require.False(t, nestedCalled)
+		require.Equal(t, 0, pool.commits)
+		require.Equal(t, 1, pool.rollbacks)
+	})
+
+	t.Run("flatten", func(t *testing.T) {
+		pool := &fakePool{}
+
+		err := open(t, pool, WithSavePointPolicy(SavePointFlatten)).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec("SELECT 1").Error; err != nil {
+				return err
+			}
+
+			return tx.Transaction(func(tx *gorm.DB) error {
+				return tx.Exec("SELECT 2").Error
+			})
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"SELECT 1", "SELECT 2"}, pool.queries)
+		require.Equal(t, 1, pool.commits)
+		require.Equal(t, 0, pool.rollbacks)
+	})
+
+	t.Run("flatten rollback", func(t *testing.T) {
+		pool := &fakePool{}
+
+		err := open(t, pool, WithSavePointPolicy(SavePointFlatten)).Transaction(func(tx *gorm.DB) error {
+			_ = tx.Transaction(func(tx *gorm.DB) error {
+				return nestedErr
+			})
+
+			return nil
+		})
+		require.ErrorIs(t, err, sql.ErrTxDone)
+		require.Equal(t, 0, pool.commits)
+		require.Equal(t, 1, pool.rollbacks, "outer transaction must be rolled back with nested one")
+	})
+}
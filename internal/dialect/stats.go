@@ -0,0 +1,269 @@
+package dialect
+
+import (
+	"context"
+	"time"
+
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"google.golang.org/grpc"
+)
+
+// QueryStatsMode is mode of query statistics collection.
+type QueryStatsMode int
+
+const (
+	// QueryStatsNone disables collection of statistics.
+	QueryStatsNone QueryStatsMode = iota
+	// QueryStatsBasic collects aggregated statistics of reads, updates and deletes per table.
+	QueryStatsBasic
+	// QueryStatsFull collects execution statistics and plan on top of QueryStatsBasic.
+	QueryStatsFull
+	// QueryStatsProfile collects detailed execution statistics of tasks and channels on top of QueryStatsFull.
+	QueryStatsProfile
+)
+
+// tableMode returns statistics mode of table service queries.
+func (m QueryStatsMode) tableMode() Ydb_Table.QueryStatsCollection_Mode {
+	switch m {
+	case QueryStatsBasic:
+		return Ydb_Table.QueryStatsCollection_STATS_COLLECTION_BASIC
+	case QueryStatsFull:
+		return Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL
+	case QueryStatsProfile:
+		return Ydb_Table.QueryStatsCollection_STATS_COLLECTION_PROFILE
+	default:
+		return Ydb_Table.QueryStatsCollection_STATS_COLLECTION_NONE
+	}
+}
+
+// queryMode returns statistics mode of query service queries.
+func (m QueryStatsMode) queryMode() Ydb_Query.StatsMode {
+	switch m {
+	case QueryStatsBasic:
+		return Ydb_Query.StatsMode_STATS_MODE_BASIC
+	case QueryStatsFull:
+		return Ydb_Query.StatsMode_STATS_MODE_FULL
+	case QueryStatsProfile:
+		return Ydb_Query.StatsMode_STATS_MODE_PROFILE
+	default:
+		return Ydb_Query.StatsMode_STATS_MODE_NONE
+	}
+}
+
+// QueryStats is statistics of query execution.
+type QueryStats struct {
+	// CPUTime is total CPU time of query.
+	CPUTime time.Duration
+	// Duration is total duration of query.
+	Duration time.Duration
+	// Tables is statistics of table accesses summed by tables over query phases.
+	Tables []TableStats
+	// Plan is query plan with execution statistics (QueryStatsFull and QueryStatsProfile modes).
+	Plan string
+	// AST is YQL AST of query (QueryStatsFull and QueryStatsProfile modes).
+	AST string
+}
+
+// RowsRead returns count of rows read from all tables.
+func (s QueryStats) RowsRead() (rows uint64) {
+	for _, t := range s.Tables {
+		rows += t.RowsRead
+	}
+
+	return rows
+}
+
+// BytesRead returns count of bytes read from all tables.
+func (s QueryStats) BytesRead() (bytes uint64) {
+	for _, t := range s.Tables {
+		bytes += t.BytesRead
+	}
+
+	return bytes
+}
+
+// TableStats is statistics of table access by query.
+type TableStats struct {
+	Name         string
+	RowsRead     uint64
+	BytesRead    uint64
+	RowsUpdated  uint64
+	BytesUpdated uint64
+	RowsDeleted  uint64
+	BytesDeleted uint64
+	Partitions   uint64
+}
+
+// WithQueryStatsCollection installs grpc interceptors which request statistics of queries with WithQueryStats
+// contexts. Interceptors are called for each grpc call of driver and look up hook of context, so they are
+// installed only with this option.
+func WithQueryStatsCollection() Option {
+	return func(d *Dialector) {
+		d.queryStats = true
+	}
+}
+
+type ctxQueryStatsKey struct{}
+
+type queryStatsHook struct {
+	mode QueryStatsMode
+	fc   func(stats QueryStats)
+}
+
+// WithQueryStats returns context which requests statistics of queries with mode and calls fc with statistics
+// of each query executed with this context. Query mode of context (for example, ScanQueryMode) is kept, and
+// statistics are collected for data, scan and query service queries. WithQueryStats has effect only for
+// Dialector with WithQueryStatsCollection option and without own Conn.
+func WithQueryStats(ctx context.Context, mode QueryStatsMode, fc func(stats QueryStats)) context.Context {
+	return context.WithValue(ctx, ctxQueryStatsKey{}, queryStatsHook{mode: mode, fc: fc})
+}
+
+func queryStatsHookOf(ctx context.Context) (queryStatsHook, bool) {
+	hook, ok := ctx.Value(ctxQueryStatsKey{}).(queryStatsHook)
+
+	return hook, ok && hook.mode != QueryStatsNone && hook.fc != nil
+}
+
+// statsGrpcOptions returns grpc options with interceptors which request statistics of queries and
+// pass them to hooks of query contexts.
+func statsGrpcOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithChainUnaryInterceptor(statsUnaryInterceptor),
+		grpc.WithChainStreamInterceptor(statsStreamInterceptor),
+	}
+}
+
+func statsUnaryInterceptor(
+	ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+) error {
+	hook, ok := queryStatsHookOf(ctx)
+	if !ok {
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+
+	requestStats(req, hook.mode)
+
+	if err := invoker(ctx, method, req, reply, cc, opts...); err != nil {
+		return err
+	}
+
+	if stats := responseStats(reply); stats != nil {
+		hook.fc(queryStatsOf(stats))
+	}
+
+	return nil
+}
+
+func statsStreamInterceptor(
+	ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+	streamer grpc.Streamer, opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	s, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	hook, ok := queryStatsHookOf(ctx)
+	if !ok {
+		return s, nil
+	}
+
+	return &statsClientStream{ClientStream: s, hook: hook}, nil
+}
+
+// statsClientStream is grpc.ClientStream which requests statistics of streaming queries.
+type statsClientStream struct {
+	grpc.ClientStream
+
+	hook queryStatsHook
+}
+
+func (s *statsClientStream) SendMsg(m interface{}) error {
+	requestStats(m, s.hook.mode)
+
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *statsClientStream) RecvMsg(m interface{}) error {
+	if err := s.ClientStream.RecvMsg(m); err != nil {
+		return err
+	}
+
+	if stats := responseStats(m); stats != nil {
+		s.hook.fc(queryStatsOf(stats))
+	}
+
+	return nil
+}
+
+// requestStats raises statistics mode of query request to mode.
+func requestStats(req interface{}, mode QueryStatsMode) {
+	switch r := req.(type) {
+	case *Ydb_Table.ExecuteDataQueryRequest:
+		if r.GetCollectStats() < mode.tableMode() {
+			r.CollectStats = mode.tableMode()
+		}
+	case *Ydb_Table.ExecuteScanQueryRequest:
+		if r.GetCollectStats() < mode.tableMode() {
+			r.CollectStats = mode.tableMode()
+		}
+	case *Ydb_Query.ExecuteQueryRequest:
+		if r.GetStatsMode() < mode.queryMode() {
+			r.StatsMode = mode.queryMode()
+		}
+	}
+}
+
+// responseStats returns statistics of query response or nil for responses without statistics.
+func responseStats(reply interface{}) *Ydb_TableStats.QueryStats {
+	switch r := reply.(type) {
+	case *Ydb_Table.ExecuteDataQueryResponse:
+		var result Ydb_Table.ExecuteQueryResult
+		if r.GetOperation().GetResult() == nil || r.GetOperation().GetResult().UnmarshalTo(&result) != nil {
+			return nil
+		}
+
+		return result.GetQueryStats()
+	case *Ydb_Table.ExecuteScanQueryPartialResponse:
+		return r.GetResult().GetQueryStats()
+	case *Ydb_Query.ExecuteQueryResponsePart:
+		return r.GetExecStats()
+	default:
+		return nil
+	}
+}
+
+func queryStatsOf(stats *Ydb_TableStats.QueryStats) QueryStats {
+	s := QueryStats{
+		CPUTime:  time.Duration(stats.GetTotalCpuTimeUs()) * time.Microsecond,
+		Duration: time.Duration(stats.GetTotalDurationUs()) * time.Microsecond,
+		Plan:     stats.GetQueryPlan(),
+		AST:      stats.GetQueryAst(),
+	}
+
+	tables := make(map[string]int)
+	for _, phase := range stats.GetQueryPhases() {
+		for _, access := range phase.GetTableAccess() {
+			i, ok := tables[access.GetName()]
+			if !ok {
+				i = len(s.Tables)
+				tables[access.GetName()] = i
+				s.Tables = append(s.Tables, TableStats{Name: access.GetName()})
+			}
+
+			t := &s.Tables[i]
+			t.RowsRead += access.GetReads().GetRows()
+			t.BytesRead += access.GetReads().GetBytes()
+			t.RowsUpdated += access.GetUpdates().GetRows()
+			t.BytesUpdated += access.GetUpdates().GetBytes()
+			t.RowsDeleted += access.GetDeletes().GetRows()
+			t.BytesDeleted += access.GetDeletes().GetBytes()
+			t.Partitions += access.GetPartitionsCount()
+		}
+	}
+
+	return s
+}
@@ -0,0 +1,176 @@
+package dialect
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Operations"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Query"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_Table"
+	"github.com/ydb-platform/ydb-go-genproto/protos/Ydb_TableStats"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+var testQueryStats = &Ydb_TableStats.QueryStats{
+	QueryPhases: []*Ydb_TableStats.QueryPhaseStats{
+		{
+			TableAccess: []*Ydb_TableStats.TableAccessStats{
+				{
+					Name:            "/local/products",
+					Reads:           &Ydb_TableStats.OperationStats{Rows: 10, Bytes: 100},
+					PartitionsCount: 2,
+				},
+			},
+		},
+		{
+			TableAccess: []*Ydb_TableStats.TableAccessStats{
+				{
+					Name:            "/local/products",
+					Reads:           &Ydb_TableStats.OperationStats{Rows: 5, Bytes: 50},
+					Updates:         &Ydb_TableStats.OperationStats{Rows: 1, Bytes: 10},
+					PartitionsCount: 1,
+				},
+				{
+					Name:    "/local/orders",
+					Deletes: &Ydb_TableStats.OperationStats{Rows: 3},
+				},
+			},
+		},
+	},
+	QueryPlan:       "{}",
+	QueryAst:        "(let ...)",
+	TotalDurationUs: 1500,
+	TotalCpuTimeUs:  700,
+}
+
+func TestQueryStatsOf(t *testing.T) {
+	stats := queryStatsOf(testQueryStats)
+	require.Equal(t, QueryStats{
+		CPUTime:  700 * time.Microsecond,
+		Duration: 1500 * time.Microsecond,
+		Tables: []TableStats{
+			{
+				Name:         "/local/products",
+				RowsRead:     15,
+				BytesRead:    150,
+				RowsUpdated:  1,
+				BytesUpdated: 10,
+				Partitions:   3,
+			},
+			{
+				Name:        "/local/orders",
+				RowsDeleted: 3,
+			},
+		},
+		Plan: "{}",
+		AST:  "(let ...)",
+	}, stats)
+	require.Equal(t, uint64(15), stats.RowsRead())
+	require.Equal(t, uint64(150), stats.BytesRead())
+}
+
+func TestStatsUnaryInterceptor(t *testing.T) {
+	result, err := anypb.New(&Ydb_Table.ExecuteQueryResult{QueryStats: testQueryStats})
+	require.NoError(t, err)
+
+	invoker := func(_ context.Context, _ string, _, reply interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		reply.(*Ydb_Table.ExecuteDataQueryResponse).Operation = &Ydb_Operations.Operation{Result: result}
+
+		return nil
+	}
+
+	t.Run("with stats", func(t *testing.T) {
+		var stats []QueryStats
+		ctx := WithQueryStats(context.Background(), QueryStatsFull, func(s QueryStats) {
+			stats = append(stats, s)
+		})
+
+		req := &Ydb_Table.ExecuteDataQueryRequest{}
+		err := statsUnaryInterceptor(ctx, "", req, &Ydb_Table.ExecuteDataQueryResponse{}, nil, invoker)
+		require.NoError(t, err)
+		require.Equal(t, Ydb_Table.QueryStatsCollection_STATS_COLLECTION_FULL, req.GetCollectStats())
+		require.Equal(t, []QueryStats{queryStatsOf(testQueryStats)}, stats)
+	})
+
+	t.Run("without stats", func(t *testing.T) {
+		req := &Ydb_Table.ExecuteDataQueryRequest{}
+		err := statsUnaryInterceptor(context.Background(), "", req, &Ydb_Table.ExecuteDataQueryResponse{}, nil, invoker)
+		require.NoError(t, err)
+		require.Equal(t, Ydb_Table.QueryStatsCollection_STATS_COLLECTION_UNSPECIFIED, req.GetCollectStats())
+	})
+
+	t.Run("without result", func(t *testing.T) {
+		var called bool
+		ctx := WithQueryStats(context.Background(), QueryStatsBasic, func(QueryStats) {
+			called = true
+		})
+
+		err := statsUnaryInterceptor(ctx, "", &Ydb_Table.ExecuteDataQueryRequest{},
+			&Ydb_Table.ExecuteDataQueryResponse{}, nil,
+			func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				return nil
+			},
+		)
+		require.NoError(t, err)
+		require.False(t, called)
+	})
+}
+
+// partsStream is grpc.ClientStream which records sent messages and receives query service response parts.
+type partsStream struct {
+	grpc.ClientStream
+
+	sent  []interface{}
+	parts []*Ydb_Query.ExecuteQueryResponsePart
+}
+
+func (s *partsStream) SendMsg(m interface{}) error {
+	s.sent = append(s.sent, m)
+
+	return nil
+}
+
+func (s *partsStream) RecvMsg(m interface{}) error {
+	part := s.parts[0]
+	s.parts = s.parts[1:]
+	m.(*Ydb_Query.ExecuteQueryResponsePart).ExecStats = part.GetExecStats()
+
+	return nil
+}
+
+func TestStatsStreamInterceptor(t *testing.T) {
+	var stats []QueryStats
+	ctx := WithQueryStats(context.Background(), QueryStatsProfile, func(s QueryStats) {
+		stats = append(stats, s)
+	})
+
+	stream := &partsStream{parts: []*Ydb_Query.ExecuteQueryResponsePart{{}, {ExecStats: testQueryStats}}}
+
+	s, err := statsStreamInterceptor(ctx, &grpc.StreamDesc{}, nil, "",
+		func(context.Context, *grpc.StreamDesc, *grpc.ClientConn, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+			return stream, nil
+		},
+	)
+	require.NoError(t, err)
+
+	req := &Ydb_Query.ExecuteQueryRequest{StatsMode: Ydb_Query.StatsMode_STATS_MODE_BASIC}
+	require.NoError(t, s.SendMsg(req))
+	require.Equal(t, Ydb_Query.StatsMode_STATS_MODE_PROFILE, req.GetStatsMode())
+
+	require.NoError(t, s.RecvMsg(&Ydb_Query.ExecuteQueryResponsePart{}))
+	require.Empty(t, stats)
+
+	require.NoError(t, s.RecvMsg(&Ydb_Query.ExecuteQueryResponsePart{}))
+	require.Equal(t, []QueryStats{queryStatsOf(testQueryStats)}, stats)
+
+	scanReq := &Ydb_Table.ExecuteScanQueryRequest{
+		CollectStats: Ydb_Table.QueryStatsCollection_STATS_COLLECTION_PROFILE,
+	}
+	requestStats(scanReq, QueryStatsBasic)
+	require.Equal(t, Ydb_Table.QueryStatsCollection_STATS_COLLECTION_PROFILE, scanReq.GetCollectStats(),
+		"stats mode of request must not be lowered",
+	)
+}
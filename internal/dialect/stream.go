@@ -0,0 +1,53 @@
+package dialect
+
+import (
+	"context"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"gorm.io/gorm"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// Stream executes query of db and calls fc for each row scanned into T.
+// Outside of transactions query is executed as scan query (table service) or as streaming query (query service),
+// so result set is not limited by server. Inside of transactions query is executed as data query, and
+// truncated result set is reported as error. Model of query is T if db has no model and table.
+func Stream[T any](db *gorm.DB, fc func(row *T) error) error {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	// queries of transactions are data queries, ydb-go-sdk fails queries of transactions with other modes
+	_, inTx := db.Statement.ConnPool.(gorm.TxCommitter)
+	if d, ok := dialectorOf(db); ok && !d.queryService && !inTx {
+		ctx = ydbDriver.WithQueryMode(ctx, ydbDriver.ScanQueryMode)
+	}
+
+	tx := db.WithContext(ctx)
+	if tx.Statement.Model == nil && tx.Statement.Table == "" {
+		tx = tx.Model(new(T))
+	}
+
+	rows, err := tx.Rows()
+	if err != nil {
+		return xerrors.WithStacktrace(err)
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	for rows.Next() {
+		row := new(T)
+		if err = tx.ScanRows(rows, row); err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+
+		if err = fc(row); err != nil {
+			return xerrors.WithStacktrace(err)
+		}
+	}
+
+	return xerrors.WithStacktrace(rows.Err())
+}
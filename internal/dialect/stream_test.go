@@ -0,0 +1,68 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestStream(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	queryErr := errors.New("query error")
+	pool := &fakePool{err: queryErr}
+
+	db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	var called bool
+	fc := func(*Product) error {
+		called = true
+
+		return nil
+	}
+
+	err = Stream(db.Where("code = ?", "D42"), fc)
+	require.ErrorIs(t, err, queryErr)
+	require.False(t, called)
+
+	err = Stream(db.Table("archived_products"), fc)
+	require.ErrorIs(t, err, queryErr)
+
+	require.Equal(t, []string{
+		"SELECT * FROM `products` WHERE code = $1",
+		"SELECT * FROM `archived_products`",
+	}, pool.queries)
+
+	scan := ydbDriver.WithQueryMode(context.Background(), ydbDriver.ScanQueryMode)
+	require.Equal(t, []context.Context{scan, scan}, pool.contexts)
+
+	t.Run("transaction", func(t *testing.T) {
+		pool := &fakePool{err: queryErr}
+
+		db, err := gorm.Open(&Dialector{Conn: pool}, &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+
+		err = db.Transaction(func(tx *gorm.DB) error {
+			return Stream(tx, fc)
+		})
+		require.ErrorIs(t, err, queryErr)
+
+		// queries of transactions are executed as data queries
+		require.Equal(t, []context.Context{context.Background()}, pool.contexts)
+	})
+}
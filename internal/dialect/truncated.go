@@ -0,0 +1,69 @@
+package dialect
+
+import (
+	"errors"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table/result"
+	"gorm.io/gorm"
+)
+
+// ErrTruncated is error of data query with result set truncated by server (more rows than result set limit).
+// It is result.ErrTruncated of ydb-go-sdk, which is returned by ydb-go-sdk for truncated result sets.
+var ErrTruncated = result.ErrTruncated
+
+// TruncatedPolicy defines behavior of queries with result set truncated by server.
+type TruncatedPolicy int
+
+const (
+	// TruncatedError keeps default behavior of ydb-go-sdk: queries with truncated result set fail with
+	// ErrTruncated. It is default policy.
+	TruncatedError TruncatedPolicy = iota
+	// TruncatedScan executes queries with truncated result set again as scan queries, which read result
+	// with parts without limit of rows. Queries inside of transactions fail with ErrTruncated.
+	TruncatedScan
+)
+
+// WithTruncatedPolicy apply policy of truncated result sets to Dialector.
+func WithTruncatedPolicy(policy TruncatedPolicy) Option {
+	return func(d *Dialector) {
+		d.truncatedPolicy = policy
+	}
+}
+
+// truncatedCallback executes query with truncated result set again as scan query.
+func (d Dialector) truncatedCallback(db *gorm.DB) {
+	if d.truncatedPolicy != TruncatedScan || db.DryRun || !isTruncated(db.Error) {
+		return
+	}
+
+	if isTx(db.Statement.ConnPool) {
+		return
+	}
+
+	db.Error = nil
+
+	rows, err := db.Statement.ConnPool.QueryContext(
+		ydbDriver.WithQueryMode(db.Statement.Context, ydbDriver.ScanQueryMode),
+		db.Statement.SQL.String(), db.Statement.Vars...,
+	)
+	if err != nil {
+		_ = db.AddError(err)
+
+		return
+	}
+	defer func() {
+		_ = db.AddError(rows.Close())
+	}()
+
+	gorm.Scan(rows, db, 0)
+}
+
+func isTruncated(err error) bool {
+	return err != nil && errors.Is(err, ErrTruncated)
+}
+
+func (d Dialector) registerTruncatedCallbacks(db *gorm.DB) error {
+	return db.Callback().Query().After("gorm:query").Before("gorm:preload").
+		Register("ydb:truncated", d.truncatedCallback)
+}
@@ -0,0 +1,66 @@
+package dialect
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestTruncatedPolicy(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	open := func(t *testing.T, pool *fakePool, opts ...Option) *gorm.DB {
+		t.Helper()
+
+		d := New("", opts...)
+		d.Conn = pool
+
+		db, err := gorm.Open(d, &gorm.Config{
+			DisableAutomaticPing: true,
+			Logger:               logger.Discard,
+		})
+		require.NoError(t, err)
+
+		return db
+	}
+
+	truncatedErr := fmt.Errorf("more than 1000 rows: %w", ErrTruncated)
+	scanErr := errors.New("scan query error")
+
+	t.Run("error", func(t *testing.T) {
+		pool := &fakePool{errs: []error{truncatedErr}, err: scanErr}
+
+		err := open(t, pool).Find(&[]Product{}).Error
+		require.ErrorIs(t, err, ErrTruncated)
+		require.Equal(t, []string{"SELECT * FROM `products`"}, pool.queries)
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		pool := &fakePool{errs: []error{truncatedErr}, err: scanErr}
+
+		err := open(t, pool, WithTruncatedPolicy(TruncatedScan)).Where("code = ?", "D42").Find(&[]Product{}).Error
+		require.ErrorIs(t, err, scanErr)
+		require.NotErrorIs(t, err, ErrTruncated)
+		require.Equal(t, []string{
+			"SELECT * FROM `products` WHERE code = $1",
+			"SELECT * FROM `products` WHERE code = $1",
+		}, pool.queries)
+	})
+
+	t.Run("scan in transaction", func(t *testing.T) {
+		pool := &fakePool{errs: []error{truncatedErr}, err: scanErr}
+
+		err := open(t, pool, WithTruncatedPolicy(TruncatedScan)).Transaction(func(tx *gorm.DB) error {
+			return tx.Find(&[]Product{}).Error
+		})
+		require.ErrorIs(t, err, ErrTruncated)
+		require.Len(t, pool.queries, 1)
+	})
+}
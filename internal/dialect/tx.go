@@ -0,0 +1,132 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	"github.com/ydb-platform/ydb-go-sdk/v3/table"
+
+	"github.com/ydb-platform/gorm-driver/internal/xerrors"
+)
+
+// TxMode is YDB transaction mode.
+type TxMode int
+
+const (
+	// SerializableReadWriteTxMode is default read-write transaction mode.
+	SerializableReadWriteTxMode TxMode = iota
+	// SnapshotReadOnlyTxMode is read-only transaction mode with reads from consistent snapshot.
+	SnapshotReadOnlyTxMode
+	// OnlineReadOnlyTxMode is read-only mode with consistent reads of each query.
+	OnlineReadOnlyTxMode
+	// OnlineReadOnlyInconsistentTxMode is read-only mode without consistency of reads from different shards.
+	OnlineReadOnlyInconsistentTxMode
+	// StaleReadOnlyTxMode is read-only mode with reads of possibly stale consistent data.
+	StaleReadOnlyTxMode
+)
+
+func (m TxMode) String() string {
+	switch m {
+	case SerializableReadWriteTxMode:
+		return "SerializableReadWrite"
+	case SnapshotReadOnlyTxMode:
+		return "SnapshotReadOnly"
+	case OnlineReadOnlyTxMode:
+		return "OnlineReadOnly"
+	case OnlineReadOnlyInconsistentTxMode:
+		return "OnlineReadOnlyInconsistent"
+	case StaleReadOnlyTxMode:
+		return "StaleReadOnly"
+	default:
+		return fmt.Sprintf("TxMode(%d)", int(m))
+	}
+}
+
+// txSettings returns table.TxOption for transaction mode.
+func (m TxMode) txSettings() table.TxOption {
+	switch m {
+	case SnapshotReadOnlyTxMode:
+		return table.WithSnapshotReadOnly()
+	case OnlineReadOnlyTxMode:
+		return table.WithOnlineReadOnly()
+	case OnlineReadOnlyInconsistentTxMode:
+		return table.WithOnlineReadOnly(table.WithInconsistentReads())
+	case StaleReadOnlyTxMode:
+		return table.WithStaleReadOnly()
+	default:
+		return table.WithSerializableReadWrite()
+	}
+}
+
+// interactive reports whether transaction mode is allowed for interactive (multi-query) transactions.
+func (m TxMode) interactive() bool {
+	return m == SerializableReadWriteTxMode || m == SnapshotReadOnlyTxMode
+}
+
+type ctxTxModeKey struct{}
+
+// WithTxMode returns context with transaction mode for queries and transactions started with this context.
+// Queries outside of transactions are executed in separate transaction with mode and commit.
+func WithTxMode(ctx context.Context, mode TxMode) context.Context {
+	ctx = context.WithValue(ctx, ctxTxModeKey{}, mode)
+
+	return ydbDriver.WithTxControl(ctx, table.TxControl(table.BeginTx(mode.txSettings()), table.CommitTx()))
+}
+
+func txModeFromContext(ctx context.Context) (TxMode, bool) {
+	mode, ok := ctx.Value(ctxTxModeKey{}).(TxMode)
+
+	return mode, ok
+}
+
+// txModeOf returns transaction mode for sql.TxOptions. Mode from context overrides default isolation level.
+func txModeOf(ctx context.Context, opts *sql.TxOptions) (TxMode, error) {
+	if opts == nil {
+		opts = &sql.TxOptions{}
+	}
+
+	if mode, ok := txModeFromContext(ctx); ok && opts.Isolation == sql.LevelDefault {
+		return mode, nil
+	}
+
+	switch opts.Isolation {
+	case sql.LevelDefault, sql.LevelSerializable:
+		if !opts.ReadOnly {
+			return SerializableReadWriteTxMode, nil
+		}
+
+		if opts.Isolation == sql.LevelDefault {
+			return SnapshotReadOnlyTxMode, nil
+		}
+	case sql.LevelSnapshot:
+		if opts.ReadOnly {
+			return SnapshotReadOnlyTxMode, nil
+		}
+	case sql.LevelReadCommitted:
+		if opts.ReadOnly {
+			return OnlineReadOnlyTxMode, nil
+		}
+	case sql.LevelReadUncommitted:
+		if opts.ReadOnly {
+			return OnlineReadOnlyInconsistentTxMode, nil
+		}
+	}
+
+	return 0, xerrors.WithStacktrace(fmt.Errorf("unsupported transaction options: %+v", *opts))
+}
+
+// txOptions returns sql.TxOptions for interactive transaction modes.
+func txOptions(mode TxMode) *sql.TxOptions {
+	if mode == SnapshotReadOnlyTxMode {
+		return &sql.TxOptions{
+			Isolation: sql.LevelSnapshot,
+			ReadOnly:  true,
+		}
+	}
+
+	return &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	}
+}
@@ -0,0 +1,107 @@
+package dialect
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithTxMode(t *testing.T) {
+	ctx := WithTxMode(context.Background(), StaleReadOnlyTxMode)
+
+	mode, ok := txModeFromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, StaleReadOnlyTxMode, mode)
+
+	_, ok = txModeFromContext(context.Background())
+	require.False(t, ok)
+}
+
+func Test_txModeOf(t *testing.T) { //nolint:funlen
+	tests := []struct {
+		name    string
+		ctx     context.Context //nolint:containedctx
+		opts    *sql.TxOptions
+		mode    TxMode
+		isError bool
+	}{
+		{
+			name: "nil options",
+			ctx:  context.Background(),
+			mode: SerializableReadWriteTxMode,
+		},
+		{
+			name: "default read-only",
+			ctx:  context.Background(),
+			opts: &sql.TxOptions{ReadOnly: true},
+			mode: SnapshotReadOnlyTxMode,
+		},
+		{
+			name: "serializable",
+			ctx:  context.Background(),
+			opts: &sql.TxOptions{Isolation: sql.LevelSerializable},
+			mode: SerializableReadWriteTxMode,
+		},
+		{
+			name: "snapshot read-only",
+			ctx:  context.Background(),
+			opts: &sql.TxOptions{Isolation: sql.LevelSnapshot, ReadOnly: true},
+			mode: SnapshotReadOnlyTxMode,
+		},
+		{
+			name: "read committed read-only",
+			ctx:  context.Background(),
+			opts: &sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: true},
+			mode: OnlineReadOnlyTxMode,
+		},
+		{
+			name: "read uncommitted read-only",
+			ctx:  context.Background(),
+			opts: &sql.TxOptions{Isolation: sql.LevelReadUncommitted, ReadOnly: true},
+			mode: OnlineReadOnlyInconsistentTxMode,
+		},
+		{
+			name: "mode from context",
+			ctx:  WithTxMode(context.Background(), StaleReadOnlyTxMode),
+			opts: &sql.TxOptions{ReadOnly: true},
+			mode: StaleReadOnlyTxMode,
+		},
+		{
+			name: "options override context",
+			ctx:  WithTxMode(context.Background(), StaleReadOnlyTxMode),
+			opts: &sql.TxOptions{Isolation: sql.LevelReadCommitted, ReadOnly: true},
+			mode: OnlineReadOnlyTxMode,
+		},
+		{
+			name:    "read committed read-write",
+			ctx:     context.Background(),
+			opts:    &sql.TxOptions{Isolation: sql.LevelReadCommitted},
+			isError: true,
+		},
+		{
+			name:    "serializable read-only",
+			ctx:     context.Background(),
+			opts:    &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true},
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mode, err := txModeOf(tt.ctx, tt.opts)
+			if tt.isError {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.mode, mode)
+		})
+	}
+}
+
+func TestTxMode_String(t *testing.T) {
+	require.Equal(t, "OnlineReadOnly", OnlineReadOnlyTxMode.String())
+	require.Equal(t, "TxMode(42)", TxMode(42).String())
+}
@@ -2,7 +2,11 @@ package dialect
 
 import (
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
+	"math"
+	"reflect"
+	"time"
 
 	"github.com/ydb-platform/ydb-go-sdk/v3/table/types"
 	"gorm.io/gorm"
@@ -111,3 +115,173 @@ func parseField(f *schema.Field) (gorm.ColumnType, types.Type, error) {
 		return nil, nil, xerrors.WithStacktrace(fmt.Errorf("unsupported data type '%s'", f.DataType))
 	}
 }
+
+// fieldType returns ydb Type of schema.Field column as it created by Migrator.
+func fieldType(f *schema.Field) (types.Type, error) {
+	_, t, err := parseField(f)
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	if !f.NotNull {
+		return types.Optional(t), nil
+	}
+
+	return t, nil
+}
+
+// toValue converts go value v to ydb Value of type t.
+func toValue(t types.Type, v interface{}) (types.Value, error) { //nolint:funlen,gocyclo
+	if isOptional, innerType := types.IsOptional(t); isOptional {
+		rv, err := indirect(v)
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		if !rv.IsValid() {
+			return types.NullValue(innerType), nil
+		}
+
+		value, err := toValue(innerType, rv.Interface())
+		if err != nil {
+			return nil, xerrors.WithStacktrace(err)
+		}
+
+		return types.OptionalValue(value), nil
+	}
+
+	rv, err := indirect(v)
+	if err != nil {
+		return nil, xerrors.WithStacktrace(err)
+	}
+
+	if !rv.IsValid() {
+		return nil, xerrors.WithStacktrace(fmt.Errorf("nil value for not optional type '%s'", t.Yql()))
+	}
+
+	switch t {
+	case types.TypeBool:
+		if rv.Kind() == reflect.Bool {
+			return types.BoolValue(rv.Bool()), nil
+		}
+	case types.TypeInt8, types.TypeInt16, types.TypeInt32, types.TypeInt64:
+		if x, ok := toInt64(rv); ok {
+			return intValue(t, x)
+		}
+	case types.TypeUint8, types.TypeUint16, types.TypeUint32, types.TypeUint64:
+		if x, ok := toUint64(rv); ok {
+			return uintValue(t, x)
+		}
+	case types.TypeFloat:
+		if rv.CanFloat() {
+			return types.FloatValue(float32(rv.Float())), nil
+		}
+	case types.TypeDouble:
+		if rv.CanFloat() {
+			return types.DoubleValue(rv.Float()), nil
+		}
+	case types.TypeText:
+		switch {
+		case rv.Kind() == reflect.String:
+			return types.TextValue(rv.String()), nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			return types.TextValue(string(rv.Bytes())), nil
+		}
+	case types.TypeBytes:
+		switch {
+		case rv.Kind() == reflect.String:
+			return types.BytesValueFromString(rv.String()), nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			return types.BytesValue(rv.Bytes()), nil
+		}
+	case types.TypeTimestamp:
+		if tt, ok := rv.Interface().(time.Time); ok {
+			return types.TimestampValueFromTime(tt), nil
+		}
+	}
+
+	return nil, xerrors.WithStacktrace(fmt.Errorf("cannot convert value of type %s to '%s'", rv.Type(), t.Yql()))
+}
+
+// indirect dereferences pointers and driver.Valuer's of v. Returns invalid reflect.Value for nil values.
+func indirect(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.IsValid() {
+		if rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return reflect.Value{}, nil
+		}
+
+		if valuer, ok := rv.Interface().(driver.Valuer); ok {
+			value, err := valuer.Value()
+			if err != nil {
+				return reflect.Value{}, xerrors.WithStacktrace(fmt.Errorf("driver.Valuer error: %w", err))
+			}
+
+			if vv := reflect.ValueOf(value); vv.Kind() != rv.Kind() || vv.Type() != rv.Type() {
+				rv = vv
+
+				continue
+			}
+		}
+
+		if rv.Kind() != reflect.Pointer {
+			break
+		}
+
+		rv = rv.Elem()
+	}
+
+	return rv, nil
+}
+
+func toInt64(rv reflect.Value) (int64, bool) {
+	switch {
+	case rv.CanInt():
+		return rv.Int(), true
+	case rv.CanUint() && rv.Uint() <= math.MaxInt64:
+		return int64(rv.Uint()), true
+	default:
+		return 0, false
+	}
+}
+
+func toUint64(rv reflect.Value) (uint64, bool) {
+	switch {
+	case rv.CanUint():
+		return rv.Uint(), true
+	case rv.CanInt() && rv.Int() >= 0:
+		return uint64(rv.Int()), true
+	default:
+		return 0, false
+	}
+}
+
+func intValue(t types.Type, x int64) (types.Value, error) {
+	switch {
+	case t == types.TypeInt8 && x >= math.MinInt8 && x <= math.MaxInt8:
+		return types.Int8Value(int8(x)), nil
+	case t == types.TypeInt16 && x >= math.MinInt16 && x <= math.MaxInt16:
+		return types.Int16Value(int16(x)), nil
+	case t == types.TypeInt32 && x >= math.MinInt32 && x <= math.MaxInt32:
+		return types.Int32Value(int32(x)), nil
+	case t == types.TypeInt64:
+		return types.Int64Value(x), nil
+	default:
+		return nil, xerrors.WithStacktrace(fmt.Errorf("value %d overflows '%s'", x, t.Yql()))
+	}
+}
+
+func uintValue(t types.Type, x uint64) (types.Value, error) {
+	switch {
+	case t == types.TypeUint8 && x <= math.MaxUint8:
+		return types.Uint8Value(uint8(x)), nil
+	case t == types.TypeUint16 && x <= math.MaxUint16:
+		return types.Uint16Value(uint16(x)), nil
+	case t == types.TypeUint32 && x <= math.MaxUint32:
+		return types.Uint32Value(uint32(x)), nil
+	case t == types.TypeUint64:
+		return types.Uint64Value(x), nil
+	default:
+		return nil, xerrors.WithStacktrace(fmt.Errorf("value %d overflows '%s'", x, t.Yql()))
+	}
+}
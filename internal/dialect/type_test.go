@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
@@ -246,3 +247,143 @@ func Test_parseField(t *testing.T) { //nolint:funlen
 		})
 	}
 }
+
+func Test_fieldType(t *testing.T) {
+	tests := []struct {
+		field     *schema.Field
+		typesType types.Type
+		isError   bool
+	}{
+		{
+			field: &schema.Field{
+				DataType: schema.Uint,
+				Size:     32,
+			},
+			typesType: types.Optional(types.TypeUint32),
+		},
+		{
+			field: &schema.Field{
+				DataType:   schema.Uint,
+				Size:       64,
+				PrimaryKey: true,
+				NotNull:    true,
+			},
+			typesType: types.TypeUint64,
+		},
+		{
+			field:   &schema.Field{},
+			isError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			typesType, err := fieldType(tt.field)
+			if tt.isError {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.typesType.Yql(), typesType.Yql())
+		})
+	}
+}
+
+func Test_toValue(t *testing.T) { //nolint:funlen
+	var (
+		nilString *string
+		text      = "text"
+		ts        = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	)
+
+	tests := []struct {
+		typesType types.Type
+		value     interface{}
+		expected  types.Value
+		isError   bool
+	}{
+		{
+			typesType: types.TypeBool,
+			value:     true,
+			expected:  types.BoolValue(true),
+		},
+		{
+			typesType: types.TypeInt8,
+			value:     int64(-8),
+			expected:  types.Int8Value(-8),
+		},
+		{
+			typesType: types.TypeInt8,
+			value:     1000,
+			isError:   true,
+		},
+		{
+			typesType: types.TypeUint32,
+			value:     uint(32),
+			expected:  types.Uint32Value(32),
+		},
+		{
+			typesType: types.TypeUint64,
+			value:     -1,
+			isError:   true,
+		},
+		{
+			typesType: types.TypeDouble,
+			value:     1.5,
+			expected:  types.DoubleValue(1.5),
+		},
+		{
+			typesType: types.TypeText,
+			value:     &text,
+			expected:  types.TextValue(text),
+		},
+		{
+			typesType: types.TypeBytes,
+			value:     []byte("bytes"),
+			expected:  types.BytesValue([]byte("bytes")),
+		},
+		{
+			typesType: types.TypeTimestamp,
+			value:     ts,
+			expected:  types.TimestampValueFromTime(ts),
+		},
+		{
+			typesType: types.Optional(types.TypeText),
+			value:     nilString,
+			expected:  types.NullValue(types.TypeText),
+		},
+		{
+			typesType: types.Optional(types.TypeInt64),
+			value:     sql.NullInt64{Int64: 64, Valid: true},
+			expected:  types.OptionalValue(types.Int64Value(64)),
+		},
+		{
+			typesType: types.Optional(types.TypeInt64),
+			value:     sql.NullInt64{},
+			expected:  types.NullValue(types.TypeInt64),
+		},
+		{
+			typesType: types.TypeText,
+			value:     nil,
+			isError:   true,
+		},
+		{
+			typesType: types.TypeBool,
+			value:     "true",
+			isError:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run("", func(t *testing.T) {
+			value, err := toValue(tt.typesType, tt.value)
+			if tt.isError {
+				require.Error(t, err)
+
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected.Yql(), value.Yql())
+			require.Equal(t, tt.expected.Type().Yql(), value.Type().Yql())
+		})
+	}
+}
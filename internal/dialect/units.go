@@ -0,0 +1,130 @@
+package dialect
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/ydb-platform/ydb-go-sdk/v3/meta"
+	"google.golang.org/grpc/metadata"
+	"gorm.io/gorm"
+)
+
+// ConsumedUnits is request units consumed by gorm statement.
+type ConsumedUnits struct {
+	// Op is gorm operation of statement.
+	Op Op
+	// Model is name of statement model (empty for statements without model).
+	Model string
+	// Table is table of statement.
+	Table string
+	// Units is sum of request units consumed by requests of statement.
+	Units uint64
+}
+
+// WithConsumedUnitsHook apply hook which called after each gorm statement with request units consumed by
+// requests of statement (`x-ydb-consumed-units` metadata of responses). Hook is called with context of
+// statement and is not called for statements without consumed units. Units of nested statements (for example,
+// associations of Create) are reported with outer statement. Units of Rows and Row statements are not reported,
+// because their results are read after statement. Hook is not applied to Dialector with own Conn.
+func WithConsumedUnitsHook(hook func(ctx context.Context, units ConsumedUnits)) Option {
+	return func(d *Dialector) {
+		d.consumedUnitsHook = hook
+	}
+}
+
+const consumedUnitsKey = "ydb:consumed_units"
+
+// ctxUnitsCounterKey is key of unitsCounter in context of statement which counts consumed units.
+type ctxUnitsCounterKey struct{}
+
+// unitsCounter is sum of consumed units of statement requests.
+type unitsCounter struct {
+	ctx   context.Context //nolint:containedctx
+	units uint64
+}
+
+// beforeConsumedUnits replaces statement context with context which counts consumed units of requests.
+// Nested statements inherit trailer callback with context of outer statement, so their units are
+// counted by outer statement only.
+func beforeConsumedUnits(db *gorm.DB) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if _, nested := ctx.Value(ctxUnitsCounterKey{}).(*unitsCounter); nested {
+		return
+	}
+
+	counter := &unitsCounter{ctx: ctx}
+	db.InstanceSet(consumedUnitsKey, counter)
+
+	ctx = context.WithValue(ctx, ctxUnitsCounterKey{}, counter)
+	db.Statement.Context = meta.WithTrailerCallback(ctx, func(md metadata.MD) {
+		atomic.AddUint64(&counter.units, meta.ConsumedUnits(md))
+	})
+}
+
+// afterConsumedUnits returns callback which restores statement context and reports consumed units of statement.
+func (d Dialector) afterConsumedUnits(op Op) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		v, ok := db.InstanceGet(consumedUnitsKey)
+		if !ok {
+			return
+		}
+
+		counter, ok := v.(*unitsCounter)
+		if !ok {
+			return
+		}
+
+		db.Statement.Context = counter.ctx
+
+		units := atomic.LoadUint64(&counter.units)
+		if units == 0 {
+			return
+		}
+
+		var model string
+		if db.Statement.Schema != nil {
+			model = db.Statement.Schema.Name
+		}
+
+		d.consumedUnitsHook(counter.ctx, ConsumedUnits{
+			Op:    opOf(db, op),
+			Model: model,
+			Table: db.Statement.Table,
+			Units: units,
+		})
+	}
+}
+
+func (d Dialector) registerConsumedUnitsCallbacks(db *gorm.DB) error {
+	if d.consumedUnitsHook == nil {
+		return nil
+	}
+
+	const (
+		before = "ydb:before_consumed_units"
+		after  = "ydb:after_consumed_units"
+	)
+
+	for _, err := range []error{
+		db.Callback().Create().Before("gorm:begin_transaction").Register(before, beforeConsumedUnits),
+		db.Callback().Create().After("gorm:commit_or_rollback_transaction").Register(after, d.afterConsumedUnits(OpCreate)),
+		db.Callback().Query().Before("gorm:query").Register(before, beforeConsumedUnits),
+		db.Callback().Query().After("gorm:after_query").Register(after, d.afterConsumedUnits(OpQuery)),
+		db.Callback().Update().Before("gorm:begin_transaction").Register(before, beforeConsumedUnits),
+		db.Callback().Update().After("gorm:commit_or_rollback_transaction").Register(after, d.afterConsumedUnits(OpUpdate)),
+		db.Callback().Delete().Before("gorm:begin_transaction").Register(before, beforeConsumedUnits),
+		db.Callback().Delete().After("gorm:commit_or_rollback_transaction").Register(after, d.afterConsumedUnits(OpDelete)),
+		db.Callback().Raw().Before("gorm:raw").Register(before, beforeConsumedUnits),
+		db.Callback().Raw().After("gorm:raw").Register(after, d.afterConsumedUnits(OpRaw)),
+	} {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
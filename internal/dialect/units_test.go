@@ -0,0 +1,78 @@
+package dialect
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestConsumedUnitsHook(t *testing.T) {
+	type Product struct {
+		ID   uint32 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	type ctxKey struct{}
+
+	var reported []ConsumedUnits
+	d := New("", WithConsumedUnitsHook(func(ctx context.Context, units ConsumedUnits) {
+		require.Equal(t, "call site", ctx.Value(ctxKey{}))
+		reported = append(reported, units)
+	}))
+	d.Conn = &fakePool{err: errors.New("query error")}
+
+	db, err := gorm.Open(d, &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+
+	// units of responses are counted by trailer callback of statement context
+	var (
+		units  uint64
+		nested bool
+	)
+	consume := func(db *gorm.DB) {
+		if v, ok := db.InstanceGet(consumedUnitsKey); ok {
+			atomic.AddUint64(&v.(*unitsCounter).units, units)
+		}
+
+		if nested {
+			nested = false
+			_ = db.Session(&gorm.Session{NewDB: true}).Exec("SELECT 2")
+		}
+	}
+	require.NoError(t, db.Callback().Query().After("gorm:query").Before("ydb:after_consumed_units").
+		Register("test:consume", consume))
+	require.NoError(t, db.Callback().Raw().After("gorm:raw").Before("ydb:after_consumed_units").
+		Register("test:consume", consume))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "call site")
+
+	units = 3
+	tx := db.WithContext(ctx).Find(&[]Product{})
+	require.Error(t, tx.Error)
+	require.Equal(t, ctx, tx.Statement.Context, "statement context not restored")
+
+	units = 0
+	require.Error(t, db.WithContext(ctx).Find(&[]Product{}).Error)
+
+	units = 2
+	require.Error(t, db.WithContext(ctx).Exec("SELECT 1").Error)
+
+	// nested statement inherits context of outer statement and is not reported
+	units, nested = 4, true
+	require.Error(t, db.WithContext(ctx).Exec("SELECT 1").Error)
+	require.False(t, nested)
+
+	require.Equal(t, []ConsumedUnits{
+		{Op: OpQuery, Model: "Product", Table: "products", Units: 3},
+		{Op: OpRaw, Units: 2},
+		{Op: OpRaw, Units: 4},
+	}, reported)
+}
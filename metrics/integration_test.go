@@ -0,0 +1,81 @@
+package metrics_test
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+	"github.com/ydb-platform/gorm-driver/metrics"
+)
+
+func TestMetrics(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	registry := prometheus.NewRegistry()
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+			metrics.WithMetrics(registry),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	err = db.Create(&Product{ID: 1, Code: "D42"}).Error
+	require.NoError(t, err)
+
+	var found []Product
+	err = db.Find(&found).Error
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+
+	modes := make(map[string]string)
+	for _, family := range families {
+		if family.GetName() != "ydb_gorm_statement_duration_seconds" {
+			continue
+		}
+
+		for _, metric := range family.GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+
+			if labels["table"] == "products" {
+				modes[labels["operation"]] = labels["query_mode"]
+			}
+		}
+	}
+
+	require.Equal(t, "data", modes["create"])
+	require.Equal(t, "data", modes["query"])
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,178 @@
+// Package metrics provides prometheus metrics of database/sql pool, ydb session pools, retries and gorm
+// statements of gorm-driver.
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+const metricsNamespace = "ydb_gorm"
+
+// metrics is ydb.StatementHook which collects driver metrics.
+type metrics struct {
+	registerer prometheus.Registerer
+
+	sessionPoolLimit    *prometheus.GaugeVec
+	sessionPoolSize     *prometheus.GaugeVec
+	sessionPoolIdle     *prometheus.GaugeVec
+	sessionPoolWaiters  *prometheus.GaugeVec
+	sessionPoolCreating *prometheus.GaugeVec
+	retries             prometheus.Counter
+	retryErrors         prometheus.Counter
+	statementDuration   *prometheus.HistogramVec
+}
+
+// WithMetrics returns option which applies prometheus registerer to Dialector. Metrics are registered on
+// Initialize of Dialector:
+//   - go_sql_* metrics of database/sql pool (sql.DBStats) with db_name="ydb";
+//   - ydb_gorm_session_pool_* gauges of ydb session pools of table and query services;
+//   - ydb_gorm_retries_total and ydb_gorm_retry_errors_total counters of retries of ydb-go-sdk and Retry helper;
+//   - ydb_gorm_statement_duration_seconds histogram of gorm statements by operation, table and query mode.
+//
+// Metrics of several databases in one registry must be distinguished with prometheus.WrapRegistererWith,
+// otherwise Initialize fails with prometheus.AlreadyRegisteredError. Session pool metrics and query modes
+// are not collected for Dialector with own Conn.
+func WithMetrics(registerer prometheus.Registerer) ydb.Option {
+	if registerer == nil {
+		return nil
+	}
+
+	return ydb.WithStatementHook(newMetrics(registerer))
+}
+
+func newMetrics(registerer prometheus.Registerer) *metrics {
+	sessionPool := func(name, help string) *prometheus.GaugeVec {
+		return prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Subsystem: "session_pool",
+			Name:      name,
+			Help:      help,
+		}, []string{"service"})
+	}
+
+	return &metrics{
+		registerer:          registerer,
+		sessionPoolLimit:    sessionPool("limit", "Limit of sessions in ydb session pool."),
+		sessionPoolSize:     sessionPool("size", "Count of sessions in ydb session pool."),
+		sessionPoolIdle:     sessionPool("idle", "Count of idle sessions in ydb session pool."),
+		sessionPoolWaiters:  sessionPool("waiters", "Count of waiters of sessions of ydb session pool."),
+		sessionPoolCreating: sessionPool("creating", "Count of sessions which are being created by ydb session pool."),
+		retries: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retries_total",
+			Help:      "Count of repeated attempts of ydb retry loops.",
+		}),
+		retryErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "retry_errors_total",
+			Help:      "Count of ydb retry loops which failed with retryable error after all attempts or with other error.",
+		}),
+		statementDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "statement_duration_seconds",
+			Help:      "Duration of gorm statements.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation", "table", "query_mode"}),
+	}
+}
+
+// Initialize registers metrics and statistics of database/sql pool conn (if conn is not nil). Metrics which are
+// registered before registration error are unregistered, so Initialize may be retried.
+func (m *metrics) Initialize(conn *sql.DB) error {
+	cs := []prometheus.Collector{
+		m.sessionPoolLimit,
+		m.sessionPoolSize,
+		m.sessionPoolIdle,
+		m.sessionPoolWaiters,
+		m.sessionPoolCreating,
+		m.retries,
+		m.retryErrors,
+		m.statementDuration,
+	}
+	if conn != nil {
+		cs = append(cs, collectors.NewDBStatsCollector(conn, "ydb"))
+	}
+
+	for i, c := range cs {
+		if err := m.registerer.Register(c); err != nil {
+			for _, registered := range cs[:i] {
+				m.registerer.Unregister(registered)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Options returns ydb options which collect metrics of session pools and retries.
+func (m *metrics) Options() []ydbDriver.Option {
+	return []ydbDriver.Option{
+		ydbDriver.WithTraceTable(ydbTrace.Table{
+			OnPoolStateChange: func(info ydbTrace.TablePoolStateChangeInfo) {
+				m.setSessionPool("table", info.Limit, info.Index, info.Idle, info.Wait, info.CreateInProgress)
+			},
+		}),
+		ydbDriver.WithTraceQuery(ydbTrace.Query{
+			OnPoolChange: func(info ydbTrace.QueryPoolChange) {
+				m.setSessionPool("query", info.Limit, info.Index, info.Idle, info.Wait, info.CreateInProgress)
+			},
+		}),
+		ydbDriver.WithTraceRetry(m.RetryTrace()),
+	}
+}
+
+func (m *metrics) setSessionPool(service string, limit, size, idle, waiters, creating int) {
+	m.sessionPoolLimit.WithLabelValues(service).Set(float64(limit))
+	m.sessionPoolSize.WithLabelValues(service).Set(float64(size))
+	m.sessionPoolIdle.WithLabelValues(service).Set(float64(idle))
+	m.sessionPoolWaiters.WithLabelValues(service).Set(float64(waiters))
+	m.sessionPoolCreating.WithLabelValues(service).Set(float64(creating))
+}
+
+func (m *metrics) RetryTrace() ydbTrace.Retry {
+	return ydbTrace.Retry{
+		OnRetry: func(info ydbTrace.RetryLoopStartInfo) func(ydbTrace.RetryLoopDoneInfo) {
+			if info.NestedCall {
+				return nil
+			}
+
+			return func(info ydbTrace.RetryLoopDoneInfo) {
+				if info.Attempts > 1 {
+					m.retries.Add(float64(info.Attempts - 1))
+				}
+
+				if info.Error != nil {
+					m.retryErrors.Inc()
+				}
+			}
+		},
+	}
+}
+
+// BeforeStatement starts measuring of statement and returns function which observes duration of statement
+// by operation, table and query mode.
+func (m *metrics) BeforeStatement(
+	ctx context.Context, info ydb.StatementInfo,
+) (context.Context, func(info ydb.StatementDoneInfo)) {
+	start := time.Now()
+
+	return ctx, func(done ydb.StatementDoneInfo) {
+		mode := done.QueryMode
+		if mode == "" {
+			mode = "unknown"
+		}
+
+		m.statementDuration.WithLabelValues(string(info.Op), done.Table, mode).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/require"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func histogramCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+
+	var metric dto.Metric
+	require.NoError(t, vec.WithLabelValues(labels...).(prometheus.Histogram).Write(&metric))
+
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestWithMetrics(t *testing.T) {
+	require.Nil(t, WithMetrics(nil))
+	require.NotNil(t, WithMetrics(prometheus.NewRegistry()))
+}
+
+func TestMetrics(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	m := newMetrics(registry)
+	require.NoError(t, m.Initialize(&sql.DB{}))
+
+	ctx := context.Background()
+
+	statementCtx, done := m.BeforeStatement(ctx, ydb.StatementInfo{Op: ydb.OpQuery})
+	require.Equal(t, ctx, statementCtx)
+	done(ydb.StatementDoneInfo{Table: "products", QueryMode: "scan", Error: errors.New("query error")})
+
+	_, done = m.BeforeStatement(ctx, ydb.StatementInfo{Op: ydb.OpRaw})
+	done(ydb.StatementDoneInfo{SQL: "SELECT 1"})
+
+	require.Equal(t, 2, testutil.CollectAndCount(m.statementDuration))
+	require.Equal(t, uint64(1), histogramCount(t, m.statementDuration, "query", "products", "scan"))
+	require.Equal(t, uint64(1), histogramCount(t, m.statementDuration, "raw", "", "unknown"))
+
+	t.Run("Retries", func(t *testing.T) {
+		m.RetryTrace().OnRetry(ydbTrace.RetryLoopStartInfo{})(ydbTrace.RetryLoopDoneInfo{Attempts: 3})
+		require.Equal(t, float64(2), testutil.ToFloat64(m.retries))
+
+		m.RetryTrace().OnRetry(ydbTrace.RetryLoopStartInfo{})(ydbTrace.RetryLoopDoneInfo{
+			Attempts: 2,
+			Error:    errors.New("retry error"),
+		})
+		require.Equal(t, float64(3), testutil.ToFloat64(m.retries))
+		require.Equal(t, float64(1), testutil.ToFloat64(m.retryErrors))
+
+		require.Nil(t, m.RetryTrace().OnRetry(ydbTrace.RetryLoopStartInfo{NestedCall: true}))
+	})
+
+	t.Run("SessionPool", func(t *testing.T) {
+		m.setSessionPool("table", 50, 10, 7, 1, 2)
+
+		require.Equal(t, float64(50), testutil.ToFloat64(m.sessionPoolLimit.WithLabelValues("table")))
+		require.Equal(t, float64(10), testutil.ToFloat64(m.sessionPoolSize.WithLabelValues("table")))
+		require.Equal(t, float64(7), testutil.ToFloat64(m.sessionPoolIdle.WithLabelValues("table")))
+		require.Equal(t, float64(1), testutil.ToFloat64(m.sessionPoolWaiters.WithLabelValues("table")))
+		require.Equal(t, float64(2), testutil.ToFloat64(m.sessionPoolCreating.WithLabelValues("table")))
+	})
+
+	t.Run("AlreadyRegistered", func(t *testing.T) {
+		err := newMetrics(registry).Initialize(nil)
+		require.ErrorAs(t, err, &prometheus.AlreadyRegisteredError{})
+	})
+
+	t.Run("RegisterError", func(t *testing.T) {
+		registry := prometheus.NewRegistry()
+
+		retryErrors := newMetrics(registry).retryErrors
+		require.NoError(t, registry.Register(retryErrors))
+
+		err := newMetrics(registry).Initialize(nil)
+		require.ErrorAs(t, err, &prometheus.AlreadyRegisteredError{})
+
+		// metrics registered before error are unregistered
+		require.True(t, registry.Unregister(retryErrors))
+		require.NoError(t, newMetrics(registry).Initialize(nil))
+	})
+}
@@ -0,0 +1,75 @@
+package otel_test
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+	"github.com/ydb-platform/gorm-driver/otel"
+)
+
+func TestTracing(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+			otel.WithTracerProvider(tp),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "test")
+
+	err = db.WithContext(ctx).Create(&Product{ID: 1, Code: "D42"}).Error
+	require.NoError(t, err)
+
+	var found []Product
+	err = db.WithContext(ctx).Find(&found).Error
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+
+	parent.End()
+
+	names := make(map[string]bool)
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() == parent.SpanContext().TraceID() {
+			names[span.Name()] = true
+		}
+	}
+
+	require.True(t, names["gorm.create"])
+	require.True(t, names["gorm.query"])
+	require.True(t, names["ydb.tx.commit"])
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,275 @@
+// Package otel provides OpenTelemetry tracing of gorm statements and ydb-go-sdk events of gorm-driver.
+package otel
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	ydbDriver "github.com/ydb-platform/ydb-go-sdk/v3"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+// tracerName is instrumentation name of driver tracer.
+const tracerName = "github.com/ydb-platform/gorm-driver/otel"
+
+// Attribute keys of spans. Keys of statement spans follow OpenTelemetry semantic conventions of databases.
+const (
+	attrDBSystem     = attribute.Key("db.system")
+	attrDBOperation  = attribute.Key("db.operation.name")
+	attrDBTable      = attribute.Key("db.collection.name")
+	attrDBStatement  = attribute.Key("db.query.text")
+	attrRowsAffected = attribute.Key("db.response.rows_affected")
+	attrQueryMode    = attribute.Key("ydb.query_mode")
+	attrSessionID    = attribute.Key("ydb.session.id")
+	attrNodeID       = attribute.Key("ydb.node.id")
+	attrTxID         = attribute.Key("ydb.tx.id")
+	attrAttempts     = attribute.Key("ydb.retry.attempts")
+	attrIdempotent   = attribute.Key("ydb.retry.idempotent")
+	attrLabel        = attribute.Key("ydb.retry.label")
+)
+
+// WithTracerProvider returns option which applies OpenTelemetry tracer provider to Dialector. Each gorm
+// statement is traced as client span with table, operation, YQL, query mode and rows affected by statement.
+// Creating and deleting of ydb sessions, retries and transactions are traced as child spans of statement
+// spans (or other spans of context), so spans of driver requests are linked to statements. Spans of
+// ydb-go-sdk events and query modes are not traced for Dialector with own Conn, and spans of Row statements
+// end before rows are read.
+func WithTracerProvider(tp trace.TracerProvider) ydb.Option {
+	if tp == nil {
+		return nil
+	}
+
+	return ydb.WithStatementHook(&tracing{tracer: tp.Tracer(tracerName)})
+}
+
+// tracing is ydb.StatementHook which traces statements and ydb-go-sdk events with tracer.
+type tracing struct {
+	tracer trace.Tracer
+}
+
+// Options returns ydb options which trace ydb-go-sdk events with tracer.
+func (t *tracing) Options() []ydbDriver.Option {
+	return []ydbDriver.Option{
+		ydbDriver.WithTraceDatabaseSQL(databaseSQLTrace(t.tracer)),
+		ydbDriver.WithTraceTable(tableTrace(t.tracer)),
+		ydbDriver.WithTraceQuery(queryTrace(t.tracer)),
+		ydbDriver.WithTraceRetry(retryTrace(t.tracer)),
+	}
+}
+
+func (t *tracing) RetryTrace() ydbTrace.Retry {
+	return retryTrace(t.tracer)
+}
+
+func (t *tracing) Initialize(*sql.DB) error {
+	return nil
+}
+
+// BeforeStatement starts span of statement and returns context of span and function which ends span with
+// YQL, query mode, rows affected and error of statement.
+func (t *tracing) BeforeStatement(
+	ctx context.Context, info ydb.StatementInfo,
+) (context.Context, func(info ydb.StatementDoneInfo)) {
+	attrs := []attribute.KeyValue{
+		attrDBSystem.String("ydb"),
+		attrDBOperation.String(string(info.Op)),
+	}
+	if info.Table != "" {
+		attrs = append(attrs, attrDBTable.String(info.Table))
+	}
+
+	ctx, span := t.tracer.Start(ctx, "gorm."+string(info.Op),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, func(info ydb.StatementDoneInfo) {
+		if info.Table != "" {
+			span.SetAttributes(attrDBTable.String(info.Table))
+		}
+
+		if info.SQL != "" {
+			span.SetAttributes(attrDBStatement.String(info.SQL))
+		}
+
+		if info.QueryMode != "" {
+			span.SetAttributes(attrQueryMode.String(info.QueryMode))
+		}
+
+		if info.RowsAffected >= 0 {
+			span.SetAttributes(attrRowsAffected.Int64(info.RowsAffected))
+		}
+
+		endSpan(span, info.Error)
+	}
+}
+
+// childSpan starts span of ydb-go-sdk event if context has span and replaces context with context of
+// new span. Returns function which ends span with error of event.
+func childSpan(
+	tracer trace.Tracer, ctx *context.Context, name string, attrs ...attribute.KeyValue,
+) func(err error, attrs ...attribute.KeyValue) {
+	if ctx == nil || *ctx == nil || !trace.SpanContextFromContext(*ctx).IsValid() {
+		return func(error, ...attribute.KeyValue) {}
+	}
+
+	var span trace.Span
+	*ctx, span = tracer.Start(*ctx, name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(attrs...),
+	)
+
+	return func(err error, attrs ...attribute.KeyValue) {
+		span.SetAttributes(attrs...)
+		endSpan(span, err)
+	}
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+func databaseSQLTrace(tracer trace.Tracer) ydbTrace.DatabaseSQL {
+	return ydbTrace.DatabaseSQL{
+		OnConnBeginTx: func(info ydbTrace.DatabaseSQLConnBeginTxStartInfo) func(ydbTrace.DatabaseSQLConnBeginTxDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.tx.begin")
+
+			return func(info ydbTrace.DatabaseSQLConnBeginTxDoneInfo) {
+				if info.Error == nil && info.Tx != nil {
+					end(nil, attrTxID.String(info.Tx.ID()))
+
+					return
+				}
+
+				end(info.Error)
+			}
+		},
+		OnTxCommit: func(info ydbTrace.DatabaseSQLTxCommitStartInfo) func(ydbTrace.DatabaseSQLTxCommitDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.tx.commit", txAttributes(info.Tx)...)
+
+			return func(info ydbTrace.DatabaseSQLTxCommitDoneInfo) {
+				end(info.Error)
+			}
+		},
+		OnTxRollback: func(info ydbTrace.DatabaseSQLTxRollbackStartInfo) func(ydbTrace.DatabaseSQLTxRollbackDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.tx.rollback", txAttributes(info.Tx)...)
+
+			return func(info ydbTrace.DatabaseSQLTxRollbackDoneInfo) {
+				end(info.Error)
+			}
+		},
+	}
+}
+
+func txAttributes(tx interface{ ID() string }) []attribute.KeyValue {
+	if tx == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{attrTxID.String(tx.ID())}
+}
+
+// sessionAttributes returns attributes of ydb session.
+func sessionAttributes(session interface {
+	ID() string
+	NodeID() uint32
+},
+) []attribute.KeyValue {
+	if session == nil {
+		return nil
+	}
+
+	return []attribute.KeyValue{
+		attrSessionID.String(session.ID()),
+		attrNodeID.Int64(int64(session.NodeID())),
+	}
+}
+
+func tableTrace(tracer trace.Tracer) ydbTrace.Table {
+	return ydbTrace.Table{
+		OnCreateSession: func(info ydbTrace.TableCreateSessionStartInfo) func(ydbTrace.TableCreateSessionDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.session.create")
+
+			return func(info ydbTrace.TableCreateSessionDoneInfo) {
+				if info.Error == nil && info.Session != nil {
+					end(nil, sessionAttributes(info.Session)...)
+
+					return
+				}
+
+				end(info.Error)
+			}
+		},
+		OnSessionDelete: func(info ydbTrace.TableSessionDeleteStartInfo) func(ydbTrace.TableSessionDeleteDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.session.delete", sessionAttributes(info.Session)...)
+
+			return func(info ydbTrace.TableSessionDeleteDoneInfo) {
+				end(info.Error)
+			}
+		},
+	}
+}
+
+func queryTrace(tracer trace.Tracer) ydbTrace.Query {
+	return ydbTrace.Query{
+		OnSessionCreate: func(info ydbTrace.QuerySessionCreateStartInfo) func(ydbTrace.QuerySessionCreateDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.session.create")
+
+			return func(info ydbTrace.QuerySessionCreateDoneInfo) {
+				if info.Error == nil && info.Session != nil {
+					end(nil, sessionAttributes(info.Session)...)
+
+					return
+				}
+
+				end(info.Error)
+			}
+		},
+		OnSessionDelete: func(info ydbTrace.QuerySessionDeleteStartInfo) func(ydbTrace.QuerySessionDeleteDoneInfo) {
+			ctx := *info.Context
+			end := childSpan(tracer, &ctx, "ydb.session.delete", sessionAttributes(info.Session)...)
+
+			return func(info ydbTrace.QuerySessionDeleteDoneInfo) {
+				end(info.Error)
+			}
+		},
+	}
+}
+
+func retryTrace(tracer trace.Tracer) ydbTrace.Retry {
+	return ydbTrace.Retry{
+		OnRetry: func(info ydbTrace.RetryLoopStartInfo) func(ydbTrace.RetryLoopDoneInfo) {
+			if info.NestedCall {
+				return nil
+			}
+
+			// context of retry loop is replaced, so requests of attempts are children of retry span
+			end := childSpan(tracer, info.Context, "ydb.retry",
+				attrIdempotent.Bool(info.Idempotent),
+				attrLabel.String(info.Label),
+			)
+
+			return func(info ydbTrace.RetryLoopDoneInfo) {
+				end(info.Error, attrAttempts.Int(info.Attempts))
+			}
+		},
+	}
+}
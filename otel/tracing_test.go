@@ -0,0 +1,123 @@
+package otel
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	ydbTrace "github.com/ydb-platform/ydb-go-sdk/v3/trace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func spanAttributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+
+	return attrs
+}
+
+func TestWithTracerProvider(t *testing.T) {
+	require.Nil(t, WithTracerProvider(nil))
+	require.NotNil(t, WithTracerProvider(sdktrace.NewTracerProvider()))
+}
+
+func TestTracing_Statement(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	hook := &tracing{tracer: tp.Tracer(tracerName)}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "parent")
+
+	statementCtx, done := hook.BeforeStatement(ctx, ydb.StatementInfo{Op: ydb.OpQuery})
+	require.Equal(t, trace.SpanContextFromContext(statementCtx).TraceID(), parent.SpanContext().TraceID())
+	require.NotEqual(t, trace.SpanContextFromContext(statementCtx).SpanID(), parent.SpanContext().SpanID())
+
+	done(ydb.StatementDoneInfo{
+		Table:     "products",
+		SQL:       "SELECT * FROM `products` WHERE code = $1",
+		QueryMode: "scan",
+		Error:     errors.New("query error"),
+	})
+
+	_, done = hook.BeforeStatement(ctx, ydb.StatementInfo{Op: ydb.OpRaw})
+	done(ydb.StatementDoneInfo{SQL: "SELECT 1", RowsAffected: -1, Error: gorm.ErrRecordNotFound})
+
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	query := spans[0]
+	require.Equal(t, "gorm.query", query.Name())
+	require.Equal(t, trace.SpanKindClient, query.SpanKind())
+	require.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	require.Equal(t, codes.Error, query.Status().Code)
+	require.Equal(t, map[attribute.Key]attribute.Value{
+		attrDBSystem:     attribute.StringValue("ydb"),
+		attrDBOperation:  attribute.StringValue("query"),
+		attrDBTable:      attribute.StringValue("products"),
+		attrDBStatement:  attribute.StringValue("SELECT * FROM `products` WHERE code = $1"),
+		attrQueryMode:    attribute.StringValue("scan"),
+		attrRowsAffected: attribute.Int64Value(0),
+	}, spanAttributes(query))
+
+	raw := spans[1]
+	require.Equal(t, "gorm.raw", raw.Name())
+	require.Equal(t, codes.Unset, raw.Status().Code)
+	require.Equal(t, map[attribute.Key]attribute.Value{
+		attrDBSystem:    attribute.StringValue("ydb"),
+		attrDBOperation: attribute.StringValue("raw"),
+		attrDBStatement: attribute.StringValue("SELECT 1"),
+	}, spanAttributes(raw))
+}
+
+func TestTracing_Events(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	tracer := tp.Tracer("test")
+
+	t.Run("NoParent", func(t *testing.T) {
+		ctx := context.Background()
+		done := retryTrace(tracer).OnRetry(ydbTrace.RetryLoopStartInfo{Context: &ctx})
+		done(ydbTrace.RetryLoopDoneInfo{Attempts: 1})
+
+		require.Equal(t, context.Background(), ctx)
+		require.Empty(t, recorder.Ended())
+	})
+
+	t.Run("Retry", func(t *testing.T) {
+		ctx, parent := tracer.Start(context.Background(), "statement")
+		parentCtx := ctx
+
+		retryErr := errors.New("retry error")
+		done := retryTrace(tracer).OnRetry(ydbTrace.RetryLoopStartInfo{
+			Context:    &ctx,
+			Label:      "test",
+			Idempotent: true,
+		})
+		require.NotEqual(t, parentCtx, ctx, "context of retry loop not replaced")
+		done(ydbTrace.RetryLoopDoneInfo{Attempts: 3, Error: retryErr})
+		parent.End()
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+		require.Equal(t, "ydb.retry", spans[0].Name())
+		require.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		require.Equal(t, codes.Error, spans[0].Status().Code)
+		require.Equal(t, map[attribute.Key]attribute.Value{
+			attrIdempotent: attribute.BoolValue(true),
+			attrLabel:      attribute.StringValue("test"),
+			attrAttempts:   attribute.IntValue(3),
+		}, spanAttributes(spans[0]))
+	})
+}
@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestBulkCreate(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+		&gorm.Config{CreateBatchSize: 100},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	db = db.Debug()
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	products := make([]Product, 0, 1000)
+	for i := 0; i < cap(products); i++ {
+		products = append(products, Product{ID: uint(i), Code: strconv.Itoa(i), Price: uint(i * 10)})
+	}
+
+	err = ydb.BulkCreate(db, &products)
+	require.NoError(t, err)
+
+	var count int64
+	err = db.Model(&Product{}).Count(&count).Error
+	require.NoError(t, err)
+	require.Equal(t, int64(len(products)), count)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,62 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestUseIndex(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint `gorm:"index:idx_products_price"`
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	db = db.Debug()
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	require.True(t, db.Migrator().HasIndex(&Product{}, "idx_products_price"))
+	require.True(t, db.Migrator().HasIndex(&Product{}, "Price"))
+	require.False(t, db.Migrator().HasIndex(&Product{}, "idx_products_code"))
+
+	err = db.Create(&Product{ID: 1, Code: "D42", Price: 100}).Error
+	require.NoError(t, err)
+
+	var products []Product
+	err = db.Clauses(ydb.UseIndex("idx_products_price")).Where("price = ?", 100).Find(&products).Error
+	require.NoError(t, err)
+	require.Len(t, products, 1)
+
+	err = db.Clauses(ydb.UseIndex("idx_products_code")).Where("code = ?", "D42").Find(&products).Error
+	require.ErrorIs(t, err, ydb.ErrIndexNotFound)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
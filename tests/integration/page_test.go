@@ -0,0 +1,83 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestPaginate(t *testing.T) {
+	type Event struct {
+		Source string    `gorm:"primarykey;not null"`
+		Time   time.Time `gorm:"primarykey;not null"`
+		Data   string
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Event{})
+	require.NoError(t, err)
+
+	start := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	events := make([]Event, 0, 25)
+	for _, source := range []string{"a", "b"} {
+		for i := 0; i < 12; i++ {
+			events = append(events, Event{Source: source, Time: start.Add(time.Duration(i) * time.Microsecond)})
+		}
+	}
+	events = append(events, Event{Source: "c", Time: start})
+
+	err = ydb.BulkCreate(db, &events)
+	require.NoError(t, err)
+
+	var (
+		pages []Event
+		token string
+		count int
+	)
+	for {
+		page, next, err := ydb.Paginate[Event](db, token, 10)
+		require.NoError(t, err)
+
+		pages = append(pages, page...)
+		count++
+
+		if next == "" {
+			break
+		}
+		token = next
+	}
+	require.Equal(t, 3, count)
+	require.Len(t, pages, len(events))
+
+	for i := range events {
+		require.Equal(t, events[i].Source, pages[i].Source)
+		require.True(t, events[i].Time.Equal(pages[i].Time))
+	}
+
+	err = db.Migrator().DropTable(&Event{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,55 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestExplainPlan(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	plan, err := ydb.ExplainPlan(db.Model(&Product{}).Where("id = ?", 42))
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.AST)
+	require.NotEmpty(t, plan.Stages)
+	require.NotEmpty(t, plan.Tables)
+	require.Empty(t, plan.FullScans())
+
+	plan, err = ydb.ExplainPlan(db.Model(&Product{}).Where("code = ?", "D42"))
+	require.NoError(t, err)
+	require.NotEmpty(t, plan.FullScans())
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
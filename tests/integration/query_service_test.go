@@ -0,0 +1,65 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestQueryService(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint `gorm:"index"`
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+			ydb.WithQueryService(),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	db = db.Debug()
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	err = db.Create(&[]Product{
+		{ID: 1, Code: "D42", Price: 100},
+		{ID: 2, Code: "F42", Price: 200},
+	}).Error
+	require.NoError(t, err)
+
+	var products []Product
+	err = db.Where("price >= ?", 100).Order("id").Find(&products).Error
+	require.NoError(t, err)
+	require.Len(t, products, 2)
+
+	err = db.Model(&products[0]).Update("Price", 300).Error
+	require.NoError(t, err)
+
+	err = db.Delete(&products[1]).Error
+	require.NoError(t, err)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestReadTable(t *testing.T) {
+	type Product struct {
+		ID    uint64 `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price *uint
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	// more rows than limit of data query result set
+	products := make([]Product, 0, 2500)
+	for i := 0; i < cap(products); i++ {
+		p := Product{ID: uint64(i), Code: strconv.Itoa(i)}
+		if i%2 == 0 {
+			price := uint(i * 10)
+			p.Price = &price
+		}
+		products = append(products, p)
+	}
+
+	err = ydb.BulkCreate(db, &products)
+	require.NoError(t, err)
+
+	var (
+		mu   sync.Mutex
+		read = make(map[uint64]Product, len(products))
+	)
+	err = ydb.ReadTable(db, func(p *Product) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		read[p.ID] = *p
+
+		return nil
+	}, ydb.WithReadTableWorkers(4))
+	require.NoError(t, err)
+	require.Len(t, read, len(products))
+
+	for _, p := range products {
+		require.Equal(t, p, read[p.ID])
+	}
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
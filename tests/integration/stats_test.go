@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestQueryStats(t *testing.T) {
+	type Product struct {
+		ID   uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code string
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+			ydb.WithQueryStatsCollection(),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	products := make([]Product, 0, 10)
+	for i := 0; i < cap(products); i++ {
+		products = append(products, Product{ID: uint(i), Code: strconv.Itoa(i)})
+	}
+
+	err = ydb.BulkCreate(db, &products)
+	require.NoError(t, err)
+
+	for name, mode := range map[string]ydb.QueryMode{
+		"data": ydb.DataQueryMode,
+		"scan": ydb.ScanQueryMode,
+	} {
+		t.Run(name, func(t *testing.T) {
+			var stats []ydb.QueryStats
+			ctx := ydb.WithQueryStats(context.Background(), ydb.QueryStatsFull, func(s ydb.QueryStats) {
+				stats = append(stats, s)
+			})
+
+			var found []Product
+			err = db.WithContext(ydb.WithQueryMode(ctx, mode)).Find(&found).Error
+			require.NoError(t, err)
+			require.Len(t, found, len(products))
+
+			require.Len(t, stats, 1)
+			require.Equal(t, uint64(len(products)), stats[0].RowsRead())
+			require.NotEmpty(t, stats[0].Plan)
+		})
+	}
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,74 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestStream(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	// more rows than limit of data query result set
+	products := make([]Product, 0, 2500)
+	for i := 0; i < cap(products); i++ {
+		products = append(products, Product{ID: uint(i), Code: strconv.Itoa(i), Price: uint(i * 10)})
+	}
+
+	err = ydb.BulkCreate(db, &products)
+	require.NoError(t, err)
+
+	var (
+		count int
+		sum   uint
+	)
+	err = ydb.Stream(db.Where("price >= ?", 0), func(p *Product) error {
+		count++
+		sum += p.Price
+
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(products), count)
+
+	var expected uint
+	for _, p := range products {
+		expected += p.Price
+	}
+	require.Equal(t, expected, sum)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,79 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+//nolint:funlen
+func TestTransaction(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	db = db.Debug()
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	var attempts int
+	err = ydb.Transaction(db, func(tx *gorm.DB) error {
+		attempts++
+
+		return tx.Create(&Product{ID: 1, Code: "D42", Price: 100}).Error
+	}, ydb.WithRetryAttempts(5))
+	require.NoError(t, err)
+	require.Equal(t, 1, attempts)
+
+	for _, opts := range []*sql.TxOptions{
+		{Isolation: sql.LevelSnapshot, ReadOnly: true},
+		{Isolation: sql.LevelReadCommitted, ReadOnly: true},
+		{Isolation: sql.LevelReadUncommitted, ReadOnly: true},
+	} {
+		var product Product
+		err = db.Transaction(func(tx *gorm.DB) error {
+			return tx.First(&product, 1).Error
+		}, opts)
+		require.NoError(t, err)
+		require.Equal(t, "D42", product.Code)
+	}
+
+	var products []Product
+	err = db.
+		WithContext(ydb.WithTxMode(context.Background(), ydb.StaleReadOnlyTxMode)).
+		Find(&products).
+		Error
+	require.NoError(t, err)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}
@@ -0,0 +1,66 @@
+package integration
+
+import (
+	"net/url"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	environ "github.com/ydb-platform/ydb-go-sdk-auth-environ"
+	"gorm.io/gorm"
+
+	ydb "github.com/ydb-platform/gorm-driver"
+)
+
+func TestTranslateError(t *testing.T) {
+	type Product struct {
+		ID    uint `gorm:"primarykey;not null;autoIncrement:false"`
+		Code  string
+		Price uint
+	}
+
+	dsn, has := os.LookupEnv("YDB_CONNECTION_STRING")
+	if !has {
+		t.Skip("skip test '" + t.Name() + "' without env 'YDB_CONNECTION_STRING'")
+	}
+
+	url, err := url.Parse(dsn)
+	require.NoError(t, err)
+
+	db, err := gorm.Open(
+		ydb.Open(dsn,
+			ydb.WithTablePathPrefix(path.Join(url.Path, t.Name())),
+			ydb.With(environ.WithEnvironCredentials()),
+		),
+		&gorm.Config{
+			TranslateError: true,
+		},
+	)
+	require.NoError(t, err)
+	require.NotNil(t, db)
+
+	db = db.Debug()
+
+	err = db.AutoMigrate(&Product{})
+	require.NoError(t, err)
+
+	err = db.Exec("INSERT INTO products (id, code, price) VALUES (1, 'D42', 100)").Error
+	require.NoError(t, err)
+
+	err = db.Exec("INSERT INTO products (id, code, price) VALUES (1, 'D42', 100)").Error
+	require.ErrorIs(t, err, gorm.ErrDuplicatedKey)
+
+	var ydbErr *ydb.Error
+	require.ErrorAs(t, err, &ydbErr)
+	require.Equal(t, ydb.OpRaw, ydbErr.Op)
+	require.Equal(t, "operation/PRECONDITION_FAILED", ydbErr.Status)
+	require.NotEmpty(t, ydbErr.Issues)
+	require.False(t, ydbErr.Retryable())
+
+	err = db.Exec("SELECT * FROM unknown_products").Error
+	require.ErrorIs(t, err, ydb.ErrSchemeError)
+
+	err = db.Migrator().DropTable(&Product{})
+	require.NoError(t, err)
+}